@@ -0,0 +1,533 @@
+// Package v1 holds the hand-maintained Go types for the schemas described by
+// the .proto files in this directory. There is no protoc-gen-gogo build step
+// wired up for this module, so these types are not real generated code: they
+// don't implement proto.Message, and their JSON (de)serialization is
+// hand-written below rather than produced by jsonpb. Keep a type here in
+// sync with its .proto by hand until a real generation step exists.
+package v1
+
+import "encoding/json"
+
+// ApplicationSpec is the top-level, user-authored description of an
+// installable application: a set of versions, each a VersionedApplicationSpec.
+type ApplicationSpec struct {
+	Versions []*VersionedApplicationSpec `json:"versions,omitempty"`
+}
+
+func (m *ApplicationSpec) GetVersions() []*VersionedApplicationSpec {
+	if m != nil {
+		return m.Versions
+	}
+	return nil
+}
+
+// VersionedApplicationSpec describes how to install one version of an
+// application: where its manifests come from (InstallationSpec), what
+// parameters/labels govern the install, and the Sprig template partials
+// available while rendering its values.
+type VersionedApplicationSpec struct {
+	Version        string            `json:"version,omitempty"`
+	ValuesYaml     string            `json:"valuesYaml,omitempty"`
+	Parameters     []*Parameter      `json:"parameters,omitempty"`
+	RequiredLabels []string          `json:"requiredLabels,omitempty"`
+	// TemplateDefinitions are named Sprig/text-template partials, keyed by
+	// name, available as {{ define }} blocks to every value template.
+	TemplateDefinitions map[string]string `json:"templateDefinitions,omitempty"`
+	// PostRenderers run, in order, against the final filtered resource set,
+	// before any caller-supplied post-renderers.
+	PostRenderers []*PostRenderer `json:"postRenderers,omitempty"`
+
+	// Types that are valid to be assigned to InstallationSpec:
+	//	*VersionedApplicationSpec_GithubChart
+	//	*VersionedApplicationSpec_HelmArchive
+	//	*VersionedApplicationSpec_ManifestsArchive
+	//	*VersionedApplicationSpec_InstallationSteps
+	//	*VersionedApplicationSpec_PluginSpec
+	//	*VersionedApplicationSpec_OciChart
+	//
+	// InstallationSpec is deliberately excluded from the default JSON
+	// encoding (see MarshalJSON/UnmarshalJSON below): real protobuf JSON
+	// flattens a oneof's chosen variant directly into the parent object
+	// rather than nesting it under a wrapper key, and `encoding/json` can't
+	// do that through a struct tag alone.
+	InstallationSpec isVersionedApplicationSpec_InstallationSpec `json:"-"`
+}
+
+type isVersionedApplicationSpec_InstallationSpec interface {
+	isVersionedApplicationSpec_InstallationSpec()
+}
+
+type VersionedApplicationSpec_GithubChart struct {
+	GithubChart *GithubRepositoryLocation `json:"githubChart,omitempty"`
+}
+
+type VersionedApplicationSpec_HelmArchive struct {
+	HelmArchive *TgzLocation `json:"helmArchive,omitempty"`
+}
+
+type VersionedApplicationSpec_ManifestsArchive struct {
+	ManifestsArchive *TgzLocation `json:"manifestsArchive,omitempty"`
+}
+
+type VersionedApplicationSpec_InstallationSteps struct {
+	InstallationSteps *InstallationSteps `json:"installationSteps,omitempty"`
+}
+
+type VersionedApplicationSpec_PluginSpec struct {
+	PluginSpec *PluginInstallationSpec `json:"pluginSpec,omitempty"`
+}
+
+type VersionedApplicationSpec_OciChart struct {
+	OciChart *OciChartLocation `json:"ociChart,omitempty"`
+}
+
+func (*VersionedApplicationSpec_GithubChart) isVersionedApplicationSpec_InstallationSpec()       {}
+func (*VersionedApplicationSpec_HelmArchive) isVersionedApplicationSpec_InstallationSpec()        {}
+func (*VersionedApplicationSpec_ManifestsArchive) isVersionedApplicationSpec_InstallationSpec()   {}
+func (*VersionedApplicationSpec_InstallationSteps) isVersionedApplicationSpec_InstallationSpec()  {}
+func (*VersionedApplicationSpec_PluginSpec) isVersionedApplicationSpec_InstallationSpec()         {}
+func (*VersionedApplicationSpec_OciChart) isVersionedApplicationSpec_InstallationSpec()           {}
+
+func (m *VersionedApplicationSpec) GetInstallationSpec() isVersionedApplicationSpec_InstallationSpec {
+	if m != nil {
+		return m.InstallationSpec
+	}
+	return nil
+}
+
+func (m *VersionedApplicationSpec) GetGithubChart() *GithubRepositoryLocation {
+	if x, ok := m.GetInstallationSpec().(*VersionedApplicationSpec_GithubChart); ok {
+		return x.GithubChart
+	}
+	return nil
+}
+
+func (m *VersionedApplicationSpec) GetHelmArchive() *TgzLocation {
+	if x, ok := m.GetInstallationSpec().(*VersionedApplicationSpec_HelmArchive); ok {
+		return x.HelmArchive
+	}
+	return nil
+}
+
+func (m *VersionedApplicationSpec) GetManifestsArchive() *TgzLocation {
+	if x, ok := m.GetInstallationSpec().(*VersionedApplicationSpec_ManifestsArchive); ok {
+		return x.ManifestsArchive
+	}
+	return nil
+}
+
+func (m *VersionedApplicationSpec) GetInstallationSteps() *InstallationSteps {
+	if x, ok := m.GetInstallationSpec().(*VersionedApplicationSpec_InstallationSteps); ok {
+		return x.InstallationSteps
+	}
+	return nil
+}
+
+func (m *VersionedApplicationSpec) GetPluginSpec() *PluginInstallationSpec {
+	if x, ok := m.GetInstallationSpec().(*VersionedApplicationSpec_PluginSpec); ok {
+		return x.PluginSpec
+	}
+	return nil
+}
+
+func (m *VersionedApplicationSpec) GetOciChart() *OciChartLocation {
+	if x, ok := m.GetInstallationSpec().(*VersionedApplicationSpec_OciChart); ok {
+		return x.OciChart
+	}
+	return nil
+}
+
+func (m *VersionedApplicationSpec) GetParameters() []*Parameter {
+	if m != nil {
+		return m.Parameters
+	}
+	return nil
+}
+
+func (m *VersionedApplicationSpec) GetRequiredLabels() []string {
+	if m != nil {
+		return m.RequiredLabels
+	}
+	return nil
+}
+
+func (m *VersionedApplicationSpec) GetTemplateDefinitions() map[string]string {
+	if m != nil {
+		return m.TemplateDefinitions
+	}
+	return nil
+}
+
+func (m *VersionedApplicationSpec) GetPostRenderers() []*PostRenderer {
+	if m != nil {
+		return m.PostRenderers
+	}
+	return nil
+}
+
+// versionedApplicationSpecJSON mirrors VersionedApplicationSpec, but with
+// InstallationSpec's variants flattened into their own fields, the way real
+// protobuf JSON encodes a oneof.
+type versionedApplicationSpecJSON struct {
+	Version             string                    `json:"version,omitempty"`
+	ValuesYaml          string                    `json:"valuesYaml,omitempty"`
+	Parameters          []*Parameter              `json:"parameters,omitempty"`
+	RequiredLabels      []string                  `json:"requiredLabels,omitempty"`
+	TemplateDefinitions map[string]string         `json:"templateDefinitions,omitempty"`
+	PostRenderers       []*PostRenderer           `json:"postRenderers,omitempty"`
+	GithubChart         *GithubRepositoryLocation `json:"githubChart,omitempty"`
+	HelmArchive         *TgzLocation              `json:"helmArchive,omitempty"`
+	ManifestsArchive    *TgzLocation              `json:"manifestsArchive,omitempty"`
+	InstallationSteps   *InstallationSteps        `json:"installationSteps,omitempty"`
+	PluginSpec          *PluginInstallationSpec   `json:"pluginSpec,omitempty"`
+	OciChart            *OciChartLocation         `json:"ociChart,omitempty"`
+}
+
+func (m *VersionedApplicationSpec) MarshalJSON() ([]byte, error) {
+	return json.Marshal(versionedApplicationSpecJSON{
+		Version:             m.Version,
+		ValuesYaml:          m.ValuesYaml,
+		Parameters:          m.Parameters,
+		RequiredLabels:      m.RequiredLabels,
+		TemplateDefinitions: m.TemplateDefinitions,
+		PostRenderers:       m.PostRenderers,
+		GithubChart:         m.GetGithubChart(),
+		HelmArchive:         m.GetHelmArchive(),
+		ManifestsArchive:    m.GetManifestsArchive(),
+		InstallationSteps:   m.GetInstallationSteps(),
+		PluginSpec:          m.GetPluginSpec(),
+		OciChart:            m.GetOciChart(),
+	})
+}
+
+func (m *VersionedApplicationSpec) UnmarshalJSON(data []byte) error {
+	var flat versionedApplicationSpecJSON
+	if err := json.Unmarshal(data, &flat); err != nil {
+		return err
+	}
+
+	m.Version = flat.Version
+	m.ValuesYaml = flat.ValuesYaml
+	m.Parameters = flat.Parameters
+	m.RequiredLabels = flat.RequiredLabels
+	m.TemplateDefinitions = flat.TemplateDefinitions
+	m.PostRenderers = flat.PostRenderers
+
+	switch {
+	case flat.GithubChart != nil:
+		m.InstallationSpec = &VersionedApplicationSpec_GithubChart{GithubChart: flat.GithubChart}
+	case flat.HelmArchive != nil:
+		m.InstallationSpec = &VersionedApplicationSpec_HelmArchive{HelmArchive: flat.HelmArchive}
+	case flat.ManifestsArchive != nil:
+		m.InstallationSpec = &VersionedApplicationSpec_ManifestsArchive{ManifestsArchive: flat.ManifestsArchive}
+	case flat.InstallationSteps != nil:
+		m.InstallationSpec = &VersionedApplicationSpec_InstallationSteps{InstallationSteps: flat.InstallationSteps}
+	case flat.PluginSpec != nil:
+		m.InstallationSpec = &VersionedApplicationSpec_PluginSpec{PluginSpec: flat.PluginSpec}
+	case flat.OciChart != nil:
+		m.InstallationSpec = &VersionedApplicationSpec_OciChart{OciChart: flat.OciChart}
+	default:
+		m.InstallationSpec = nil
+	}
+	return nil
+}
+
+type GithubRepositoryLocation struct {
+	Org       string `json:"org,omitempty"`
+	Repo      string `json:"repo,omitempty"`
+	Ref       string `json:"ref,omitempty"`
+	Directory string `json:"directory,omitempty"`
+}
+
+func (m *GithubRepositoryLocation) GetOrg() string {
+	if m != nil {
+		return m.Org
+	}
+	return ""
+}
+
+func (m *GithubRepositoryLocation) GetRepo() string {
+	if m != nil {
+		return m.Repo
+	}
+	return ""
+}
+
+func (m *GithubRepositoryLocation) GetRef() string {
+	if m != nil {
+		return m.Ref
+	}
+	return ""
+}
+
+func (m *GithubRepositoryLocation) GetDirectory() string {
+	if m != nil {
+		return m.Directory
+	}
+	return ""
+}
+
+type TgzLocation struct {
+	Uri string `json:"uri,omitempty"`
+}
+
+func (m *TgzLocation) GetUri() string {
+	if m != nil {
+		return m.Uri
+	}
+	return ""
+}
+
+type InstallationSteps struct {
+	Steps []*InstallationSteps_Step `json:"steps,omitempty"`
+}
+
+func (m *InstallationSteps) GetSteps() []*InstallationSteps_Step {
+	if m != nil {
+		return m.Steps
+	}
+	return nil
+}
+
+type InstallationSteps_Step struct {
+	Name string `json:"name,omitempty"`
+
+	// Types that are valid to be assigned to Step:
+	//	*InstallationSteps_Step_GithubChart
+	//	*InstallationSteps_Step_HelmArchive
+	//	*InstallationSteps_Step_ManifestsArchive
+	//	*InstallationSteps_Step_PluginSpec
+	//
+	// Step is excluded from the default JSON encoding for the same reason as
+	// VersionedApplicationSpec.InstallationSpec; see MarshalJSON/UnmarshalJSON
+	// below.
+	Step isInstallationSteps_Step_Step `json:"-"`
+}
+
+type isInstallationSteps_Step_Step interface {
+	isInstallationSteps_Step_Step()
+}
+
+type InstallationSteps_Step_GithubChart struct {
+	GithubChart *GithubRepositoryLocation `json:"githubChart,omitempty"`
+}
+
+type InstallationSteps_Step_HelmArchive struct {
+	HelmArchive *TgzLocation `json:"helmArchive,omitempty"`
+}
+
+type InstallationSteps_Step_ManifestsArchive struct {
+	ManifestsArchive *TgzLocation `json:"manifestsArchive,omitempty"`
+}
+
+type InstallationSteps_Step_PluginSpec struct {
+	PluginSpec *PluginInstallationSpec `json:"pluginSpec,omitempty"`
+}
+
+func (*InstallationSteps_Step_GithubChart) isInstallationSteps_Step_Step()       {}
+func (*InstallationSteps_Step_HelmArchive) isInstallationSteps_Step_Step()       {}
+func (*InstallationSteps_Step_ManifestsArchive) isInstallationSteps_Step_Step()  {}
+func (*InstallationSteps_Step_PluginSpec) isInstallationSteps_Step_Step()        {}
+
+func (m *InstallationSteps_Step) GetStep() isInstallationSteps_Step_Step {
+	if m != nil {
+		return m.Step
+	}
+	return nil
+}
+
+func (m *InstallationSteps_Step) GetGithubChart() *GithubRepositoryLocation {
+	if x, ok := m.GetStep().(*InstallationSteps_Step_GithubChart); ok {
+		return x.GithubChart
+	}
+	return nil
+}
+
+func (m *InstallationSteps_Step) GetHelmArchive() *TgzLocation {
+	if x, ok := m.GetStep().(*InstallationSteps_Step_HelmArchive); ok {
+		return x.HelmArchive
+	}
+	return nil
+}
+
+func (m *InstallationSteps_Step) GetManifestsArchive() *TgzLocation {
+	if x, ok := m.GetStep().(*InstallationSteps_Step_ManifestsArchive); ok {
+		return x.ManifestsArchive
+	}
+	return nil
+}
+
+func (m *InstallationSteps_Step) GetPluginSpec() *PluginInstallationSpec {
+	if x, ok := m.GetStep().(*InstallationSteps_Step_PluginSpec); ok {
+		return x.PluginSpec
+	}
+	return nil
+}
+
+// installationStepsStepJSON mirrors InstallationSteps_Step, but with Step's
+// variants flattened into their own fields, the way real protobuf JSON
+// encodes a oneof.
+type installationStepsStepJSON struct {
+	Name             string                    `json:"name,omitempty"`
+	GithubChart      *GithubRepositoryLocation `json:"githubChart,omitempty"`
+	HelmArchive      *TgzLocation              `json:"helmArchive,omitempty"`
+	ManifestsArchive *TgzLocation              `json:"manifestsArchive,omitempty"`
+	PluginSpec       *PluginInstallationSpec   `json:"pluginSpec,omitempty"`
+}
+
+func (m *InstallationSteps_Step) MarshalJSON() ([]byte, error) {
+	return json.Marshal(installationStepsStepJSON{
+		Name:             m.Name,
+		GithubChart:      m.GetGithubChart(),
+		HelmArchive:      m.GetHelmArchive(),
+		ManifestsArchive: m.GetManifestsArchive(),
+		PluginSpec:       m.GetPluginSpec(),
+	})
+}
+
+func (m *InstallationSteps_Step) UnmarshalJSON(data []byte) error {
+	var flat installationStepsStepJSON
+	if err := json.Unmarshal(data, &flat); err != nil {
+		return err
+	}
+
+	m.Name = flat.Name
+	switch {
+	case flat.GithubChart != nil:
+		m.Step = &InstallationSteps_Step_GithubChart{GithubChart: flat.GithubChart}
+	case flat.HelmArchive != nil:
+		m.Step = &InstallationSteps_Step_HelmArchive{HelmArchive: flat.HelmArchive}
+	case flat.ManifestsArchive != nil:
+		m.Step = &InstallationSteps_Step_ManifestsArchive{ManifestsArchive: flat.ManifestsArchive}
+	case flat.PluginSpec != nil:
+		m.Step = &InstallationSteps_Step_PluginSpec{PluginSpec: flat.PluginSpec}
+	default:
+		m.Step = nil
+	}
+	return nil
+}
+
+type Flavor struct {
+	CustomizationLayers []*CustomizationLayer `json:"customizationLayers,omitempty"`
+	Parameters          []*Parameter          `json:"parameters,omitempty"`
+}
+
+func (m *Flavor) GetCustomizationLayers() []*CustomizationLayer {
+	if m != nil {
+		return m.CustomizationLayers
+	}
+	return nil
+}
+
+func (m *Flavor) GetParameters() []*Parameter {
+	if m != nil {
+		return m.Parameters
+	}
+	return nil
+}
+
+// CustomizationLayer is one layer of a Flavor's customization, e.g.
+// "observability" or "mtls": operators pick one of its Options via
+// render.LayerInput.
+type CustomizationLayer struct {
+	Id       string         `json:"id,omitempty"`
+	Optional bool           `json:"optional,omitempty"`
+	Options  []*LayerOption `json:"options,omitempty"`
+}
+
+func (m *CustomizationLayer) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *CustomizationLayer) GetOptional() bool {
+	if m != nil {
+		return m.Optional
+	}
+	return false
+}
+
+func (m *CustomizationLayer) GetOptions() []*LayerOption {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+type LayerOption struct {
+	Id                   string                `json:"id,omitempty"`
+	HelmValues           string                `json:"helmValues,omitempty"`
+	Parameters           []*Parameter          `json:"parameters,omitempty"`
+	ResourceDependencies []*ResourceDependency `json:"resourceDependencies,omitempty"`
+}
+
+func (m *LayerOption) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *LayerOption) GetHelmValues() string {
+	if m != nil {
+		return m.HelmValues
+	}
+	return ""
+}
+
+func (m *LayerOption) GetParameters() []*Parameter {
+	if m != nil {
+		return m.Parameters
+	}
+	return nil
+}
+
+func (m *LayerOption) GetResourceDependencies() []*ResourceDependency {
+	if m != nil {
+		return m.ResourceDependencies
+	}
+	return nil
+}
+
+type Parameter struct {
+	Name     string `json:"name,omitempty"`
+	Required bool   `json:"required,omitempty"`
+}
+
+func (m *Parameter) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Parameter) GetRequired() bool {
+	if m != nil {
+		return m.Required
+	}
+	return false
+}
+
+// ResourceDependency is a cluster-side precondition (an installed CRD, a
+// registered mesh, ...) a LayerOption requires before its manifests can be
+// applied.
+type ResourceDependency struct {
+	Kind string `json:"kind,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+func (m *ResourceDependency) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *ResourceDependency) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
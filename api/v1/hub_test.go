@@ -0,0 +1,70 @@
+package v1
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestVersionedApplicationSpecJSONRoundTrip(t *testing.T) {
+	spec := &VersionedApplicationSpec{
+		Version:    "1.0.0",
+		ValuesYaml: "foo: bar",
+		InstallationSpec: &VersionedApplicationSpec_OciChart{
+			OciChart: &OciChartLocation{Ref: "oci://example.com/charts/demo:1.0.0"},
+		},
+	}
+
+	data, err := json.Marshal(spec)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	// Real protobuf JSON flattens the chosen oneof variant into the parent
+	// object rather than nesting it under a wrapper key.
+	var flat map[string]interface{}
+	if err := json.Unmarshal(data, &flat); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if _, ok := flat["ociChart"]; !ok {
+		t.Fatalf("Marshal() = %s, want a top-level ociChart field", data)
+	}
+	if _, ok := flat["installationSpec"]; ok {
+		t.Fatalf("Marshal() = %s, want no nested installationSpec wrapper key", data)
+	}
+
+	var got VersionedApplicationSpec
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if got.GetOciChart().GetRef() != "oci://example.com/charts/demo:1.0.0" {
+		t.Fatalf("round-tripped OciChart = %v, want ref preserved", got.GetOciChart())
+	}
+	if got.Version != spec.Version || got.ValuesYaml != spec.ValuesYaml {
+		t.Fatalf("round-tripped spec = %+v, want %+v", got, spec)
+	}
+}
+
+func TestInstallationStepsStepJSONRoundTrip(t *testing.T) {
+	step := &InstallationSteps_Step{
+		Name: "install-crds",
+		Step: &InstallationSteps_Step_PluginSpec{
+			PluginSpec: &PluginInstallationSpec{Kind: "kustomize", Config: "overlay: prod"},
+		},
+	}
+
+	data, err := json.Marshal(step)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	var got InstallationSteps_Step
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if got.GetPluginSpec().GetKind() != "kustomize" {
+		t.Fatalf("round-tripped step = %+v, want PluginSpec.Kind preserved", got)
+	}
+	if got.Name != step.Name {
+		t.Fatalf("round-tripped step.Name = %v, want %v", got.Name, step.Name)
+	}
+}
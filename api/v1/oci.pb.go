@@ -0,0 +1,81 @@
+package v1
+
+// OciChartLocation pulls a chart tarball from an OCI-compliant registry
+// (oci://registry/repo:tag), optionally pinning its digest and verifying a
+// cosign signature before it's rendered.
+type OciChartLocation struct {
+	// Ref is the OCI reference to pull, e.g. "oci://registry.example.com/charts/istio:1.18.0".
+	Ref string `json:"ref,omitempty"`
+	// Digest pins the expected content digest of the pulled chart; if set
+	// and it doesn't match, the pull fails rather than rendering an
+	// unexpected chart.
+	Digest string `json:"digest,omitempty"`
+	// Verify requires a valid cosign signature for CosignPublicKey before
+	// the pulled chart is allowed to render.
+	Verify bool `json:"verify,omitempty"`
+	// CosignPublicKey is the public key Verify checks the pulled chart's
+	// signature against.
+	CosignPublicKey string `json:"cosignPublicKey,omitempty"`
+
+	Auth *OciChartLocation_AuthOptions `json:"auth,omitempty"`
+}
+
+func (m *OciChartLocation) GetRef() string {
+	if m != nil {
+		return m.Ref
+	}
+	return ""
+}
+
+func (m *OciChartLocation) GetDigest() string {
+	if m != nil {
+		return m.Digest
+	}
+	return ""
+}
+
+func (m *OciChartLocation) GetVerify() bool {
+	if m != nil {
+		return m.Verify
+	}
+	return false
+}
+
+func (m *OciChartLocation) GetCosignPublicKey() string {
+	if m != nil {
+		return m.CosignPublicKey
+	}
+	return ""
+}
+
+func (m *OciChartLocation) GetAuth() *OciChartLocation_AuthOptions {
+	if m != nil {
+		return m.Auth
+	}
+	return nil
+}
+
+// OciChartLocation_AuthOptions configures how the OCI registry client
+// authenticates; the zero value pulls anonymously.
+type OciChartLocation_AuthOptions struct {
+	// DockerConfig is a path to a Docker-style config.json containing
+	// registry credentials.
+	DockerConfig string `json:"dockerConfig,omitempty"`
+	// BearerToken is used as a bearer token against the registry instead of
+	// a credentials file.
+	BearerToken string `json:"bearerToken,omitempty"`
+}
+
+func (m *OciChartLocation_AuthOptions) GetDockerConfig() string {
+	if m != nil {
+		return m.DockerConfig
+	}
+	return ""
+}
+
+func (m *OciChartLocation_AuthOptions) GetBearerToken() string {
+	if m != nil {
+		return m.BearerToken
+	}
+	return ""
+}
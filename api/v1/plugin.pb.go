@@ -0,0 +1,27 @@
+package v1
+
+// PluginInstallationSpec routes manifest rendering to whichever
+// render.ManifestSource was registered (in-process or discovered on disk)
+// for Kind, passing it Config unparsed.
+type PluginInstallationSpec struct {
+	// Kind selects the registered plugin.ManifestSource, e.g. "kustomize",
+	// "jsonnet".
+	Kind string `json:"kind,omitempty"`
+	// Config is the plugin-specific configuration payload, passed to the
+	// plugin verbatim.
+	Config string `json:"config,omitempty"`
+}
+
+func (m *PluginInstallationSpec) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *PluginInstallationSpec) GetConfig() string {
+	if m != nil {
+		return m.Config
+	}
+	return ""
+}
@@ -0,0 +1,248 @@
+package v1
+
+import "encoding/json"
+
+// PostRenderer is one step of an application spec's postRender: pipeline,
+// applied to the final, filtered resource set after manifest rendering.
+type PostRenderer struct {
+	// Types that are valid to be assigned to Kind:
+	//	*PostRenderer_LabelsAndAnnotations
+	//	*PostRenderer_ImageTagRewrite
+	//	*PostRenderer_NamespacePin
+	//	*PostRenderer_StrategicMergePatch
+	//	*PostRenderer_JsonPatch
+	//	*PostRenderer_Exec
+	//
+	// Kind is excluded from the default JSON encoding; see
+	// MarshalJSON/UnmarshalJSON below, which flatten the chosen variant into
+	// the parent object the way real protobuf JSON encodes a oneof.
+	Kind isPostRenderer_Kind `json:"-"`
+}
+
+type isPostRenderer_Kind interface {
+	isPostRenderer_Kind()
+}
+
+type PostRenderer_LabelsAndAnnotations struct {
+	LabelsAndAnnotations *LabelsAndAnnotations `json:"labelsAndAnnotations,omitempty"`
+}
+
+type PostRenderer_ImageTagRewrite struct {
+	ImageTagRewrite *ImageTagRewrite `json:"imageTagRewrite,omitempty"`
+}
+
+type PostRenderer_NamespacePin struct {
+	NamespacePin *NamespacePin `json:"namespacePin,omitempty"`
+}
+
+type PostRenderer_StrategicMergePatch struct {
+	StrategicMergePatch *StrategicMergePatch `json:"strategicMergePatch,omitempty"`
+}
+
+type PostRenderer_JsonPatch struct {
+	JsonPatch *JsonPatch `json:"jsonPatch,omitempty"`
+}
+
+type PostRenderer_Exec struct {
+	Exec *Exec `json:"exec,omitempty"`
+}
+
+func (*PostRenderer_LabelsAndAnnotations) isPostRenderer_Kind() {}
+func (*PostRenderer_ImageTagRewrite) isPostRenderer_Kind()      {}
+func (*PostRenderer_NamespacePin) isPostRenderer_Kind()         {}
+func (*PostRenderer_StrategicMergePatch) isPostRenderer_Kind()  {}
+func (*PostRenderer_JsonPatch) isPostRenderer_Kind()            {}
+func (*PostRenderer_Exec) isPostRenderer_Kind()                 {}
+
+func (m *PostRenderer) GetKind() isPostRenderer_Kind {
+	if m != nil {
+		return m.Kind
+	}
+	return nil
+}
+
+func (m *PostRenderer) GetLabelsAndAnnotations() *LabelsAndAnnotations {
+	if x, ok := m.GetKind().(*PostRenderer_LabelsAndAnnotations); ok {
+		return x.LabelsAndAnnotations
+	}
+	return nil
+}
+
+func (m *PostRenderer) GetImageTagRewrite() *ImageTagRewrite {
+	if x, ok := m.GetKind().(*PostRenderer_ImageTagRewrite); ok {
+		return x.ImageTagRewrite
+	}
+	return nil
+}
+
+func (m *PostRenderer) GetNamespacePin() *NamespacePin {
+	if x, ok := m.GetKind().(*PostRenderer_NamespacePin); ok {
+		return x.NamespacePin
+	}
+	return nil
+}
+
+func (m *PostRenderer) GetStrategicMergePatch() *StrategicMergePatch {
+	if x, ok := m.GetKind().(*PostRenderer_StrategicMergePatch); ok {
+		return x.StrategicMergePatch
+	}
+	return nil
+}
+
+func (m *PostRenderer) GetJsonPatch() *JsonPatch {
+	if x, ok := m.GetKind().(*PostRenderer_JsonPatch); ok {
+		return x.JsonPatch
+	}
+	return nil
+}
+
+func (m *PostRenderer) GetExec() *Exec {
+	if x, ok := m.GetKind().(*PostRenderer_Exec); ok {
+		return x.Exec
+	}
+	return nil
+}
+
+// postRendererJSON mirrors PostRenderer, but with Kind's variants flattened
+// into their own fields, the way real protobuf JSON encodes a oneof.
+type postRendererJSON struct {
+	LabelsAndAnnotations *LabelsAndAnnotations `json:"labelsAndAnnotations,omitempty"`
+	ImageTagRewrite      *ImageTagRewrite      `json:"imageTagRewrite,omitempty"`
+	NamespacePin         *NamespacePin         `json:"namespacePin,omitempty"`
+	StrategicMergePatch  *StrategicMergePatch  `json:"strategicMergePatch,omitempty"`
+	JsonPatch            *JsonPatch            `json:"jsonPatch,omitempty"`
+	Exec                 *Exec                 `json:"exec,omitempty"`
+}
+
+func (m *PostRenderer) MarshalJSON() ([]byte, error) {
+	return json.Marshal(postRendererJSON{
+		LabelsAndAnnotations: m.GetLabelsAndAnnotations(),
+		ImageTagRewrite:      m.GetImageTagRewrite(),
+		NamespacePin:         m.GetNamespacePin(),
+		StrategicMergePatch:  m.GetStrategicMergePatch(),
+		JsonPatch:            m.GetJsonPatch(),
+		Exec:                 m.GetExec(),
+	})
+}
+
+func (m *PostRenderer) UnmarshalJSON(data []byte) error {
+	var flat postRendererJSON
+	if err := json.Unmarshal(data, &flat); err != nil {
+		return err
+	}
+
+	switch {
+	case flat.LabelsAndAnnotations != nil:
+		m.Kind = &PostRenderer_LabelsAndAnnotations{LabelsAndAnnotations: flat.LabelsAndAnnotations}
+	case flat.ImageTagRewrite != nil:
+		m.Kind = &PostRenderer_ImageTagRewrite{ImageTagRewrite: flat.ImageTagRewrite}
+	case flat.NamespacePin != nil:
+		m.Kind = &PostRenderer_NamespacePin{NamespacePin: flat.NamespacePin}
+	case flat.StrategicMergePatch != nil:
+		m.Kind = &PostRenderer_StrategicMergePatch{StrategicMergePatch: flat.StrategicMergePatch}
+	case flat.JsonPatch != nil:
+		m.Kind = &PostRenderer_JsonPatch{JsonPatch: flat.JsonPatch}
+	case flat.Exec != nil:
+		m.Kind = &PostRenderer_Exec{Exec: flat.Exec}
+	default:
+		m.Kind = nil
+	}
+	return nil
+}
+
+// LabelsAndAnnotations injects the given labels and annotations into every
+// rendered resource.
+type LabelsAndAnnotations struct {
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+func (m *LabelsAndAnnotations) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *LabelsAndAnnotations) GetAnnotations() map[string]string {
+	if m != nil {
+		return m.Annotations
+	}
+	return nil
+}
+
+// ImageTagRewrite rewrites container images matching one of the keys in
+// Rewrites to its mapped replacement.
+type ImageTagRewrite struct {
+	Rewrites map[string]string `json:"rewrites,omitempty"`
+}
+
+func (m *ImageTagRewrite) GetRewrites() map[string]string {
+	if m != nil {
+		return m.Rewrites
+	}
+	return nil
+}
+
+// NamespacePin overrides every rendered resource's namespace.
+type NamespacePin struct {
+	Namespace string `json:"namespace,omitempty"`
+}
+
+func (m *NamespacePin) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+// StrategicMergePatch applies each of Patches as a kustomize-style
+// strategic-merge patch, matched against the resource it targets by
+// apiVersion/kind/metadata.name/metadata.namespace.
+type StrategicMergePatch struct {
+	Patches []string `json:"patches,omitempty"`
+}
+
+func (m *StrategicMergePatch) GetPatches() []string {
+	if m != nil {
+		return m.Patches
+	}
+	return nil
+}
+
+// JsonPatch applies an RFC 6902 JSON patch to the resource matched by each
+// selector document, the same way StrategicMergePatch matches its patches.
+type JsonPatch struct {
+	// Patches maps a target selector document (apiVersion/kind/name/namespace)
+	// to the RFC 6902 JSON patch to apply to it.
+	Patches map[string]string `json:"patches,omitempty"`
+}
+
+func (m *JsonPatch) GetPatches() map[string]string {
+	if m != nil {
+		return m.Patches
+	}
+	return nil
+}
+
+// Exec shells out to an external post-renderer binary, sending the
+// rendered YAML on stdin and reading the transformed YAML back from
+// stdout, the same contract Helm's --post-renderer flag uses.
+type Exec struct {
+	Command string   `json:"command,omitempty"`
+	Args    []string `json:"args,omitempty"`
+}
+
+func (m *Exec) GetCommand() string {
+	if m != nil {
+		return m.Command
+	}
+	return ""
+}
+
+func (m *Exec) GetArgs() []string {
+	if m != nil {
+		return m.Args
+	}
+	return nil
+}
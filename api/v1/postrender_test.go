@@ -0,0 +1,38 @@
+package v1
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestPostRendererJSONRoundTrip(t *testing.T) {
+	renderer := &PostRenderer{
+		Kind: &PostRenderer_ImageTagRewrite{
+			ImageTagRewrite: &ImageTagRewrite{Rewrites: map[string]string{"old:tag": "new:tag"}},
+		},
+	}
+
+	data, err := json.Marshal(renderer)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	var flat map[string]interface{}
+	if err := json.Unmarshal(data, &flat); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if _, ok := flat["imageTagRewrite"]; !ok {
+		t.Fatalf("Marshal() = %s, want a top-level imageTagRewrite field", data)
+	}
+	if _, ok := flat["kind"]; ok {
+		t.Fatalf("Marshal() = %s, want no nested kind wrapper key", data)
+	}
+
+	var got PostRenderer
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if got.GetImageTagRewrite().GetRewrites()["old:tag"] != "new:tag" {
+		t.Fatalf("round-tripped renderer = %+v, want rewrite preserved", got)
+	}
+}
@@ -0,0 +1,127 @@
+package plugin
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	errors "github.com/rotisserie/eris"
+	"github.com/solo-io/go-utils/installutils"
+	"github.com/solo-io/service-mesh-hub/pkg/plugin"
+	"github.com/spf13/cobra"
+)
+
+// defaultPluginDir is where meshctl looks for installed plugins, mirroring
+// Helm's $HELM_PLUGINS layout. It's the same directory render.renderStages
+// discovers plugins from, so an install here is immediately usable.
+func defaultPluginDir() string {
+	dirs := plugin.DefaultDirs()
+	if len(dirs) == 0 {
+		return ""
+	}
+	return dirs[0]
+}
+
+// NewPluginCmd returns the `meshctl plugin` command and its list/install/remove
+// subcommands, mirroring Helm's plugin command layout.
+func NewPluginCmd(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "plugin",
+		Short: "Manage service-mesh-hub render plugins",
+	}
+
+	cmd.AddCommand(
+		newPluginListCmd(out),
+		newPluginInstallCmd(out),
+		newPluginRemoveCmd(out),
+	)
+
+	return cmd
+}
+
+func newPluginListCmd(out io.Writer) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List installed plugins",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			plugins, err := plugin.FindPlugins([]string{defaultPluginDir()})
+			if err != nil {
+				return err
+			}
+			if len(plugins) == 0 {
+				fmt.Fprintln(out, "no plugins installed")
+				return nil
+			}
+			for _, p := range plugins {
+				fmt.Fprintf(out, "%v\t%v\t%v\n", p.Name, p.Version, p.Kind)
+			}
+			return nil
+		},
+	}
+}
+
+func newPluginInstallCmd(out io.Writer) *cobra.Command {
+	return &cobra.Command{
+		Use:   "install <archive-uri>",
+		Short: "Install a plugin from a tarball URI",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uri := args[0]
+
+			pluginDir := defaultPluginDir()
+			if err := os.MkdirAll(pluginDir, 0o755); err != nil {
+				return err
+			}
+
+			manifests, err := installutils.GetManifestsFromRemoteTar(uri)
+			if err != nil {
+				return errors.Wrapf(err, "failed to download plugin archive %v", uri)
+			}
+
+			installDir, err := ioutil.TempDir(pluginDir, "plugin-")
+			if err != nil {
+				return err
+			}
+			if err := manifests.Write(installDir); err != nil {
+				return errors.Wrapf(err, "failed to unpack plugin archive %v", uri)
+			}
+
+			p, err := plugin.LoadPlugin(installDir)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(out, "installed plugin %v (kind %v)\n", p.Name, p.Kind)
+			return nil
+		},
+	}
+}
+
+func newPluginRemoveCmd(out io.Writer) *cobra.Command {
+	return &cobra.Command{
+		Use:   "remove <name>",
+		Short: "Remove an installed plugin",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+
+			plugins, err := plugin.FindPlugins([]string{defaultPluginDir()})
+			if err != nil {
+				return err
+			}
+			for _, p := range plugins {
+				if p.Name != name {
+					continue
+				}
+				if err := os.RemoveAll(p.Dir); err != nil {
+					return err
+				}
+				fmt.Fprintf(out, "removed plugin %v\n", name)
+				return nil
+			}
+
+			return errors.Errorf("plugin %v not found", name)
+		},
+	}
+}
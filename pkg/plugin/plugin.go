@@ -0,0 +1,94 @@
+package plugin
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	errors "github.com/rotisserie/eris"
+	"gopkg.in/yaml.v2"
+)
+
+// ManifestName is the file every plugin directory must contain describing
+// itself, mirroring Helm's plugin.yaml layout.
+const ManifestName = "plugin.yaml"
+
+var (
+	MissingManifestError = func(dir string) error {
+		return errors.Errorf("plugin directory %v does not contain a %v", dir, ManifestName)
+	}
+
+	InvalidManifestError = func(dir string, err error) error {
+		return errors.Wrapf(err, "invalid %v in plugin directory %v", ManifestName, dir)
+	}
+)
+
+// Metadata describes a plugin as declared in its plugin.yaml. Kind is the
+// PluginInstallationSpec kind it registers itself as a manifest source for;
+// Command is the executable (relative to the plugin directory, or on PATH)
+// invoked to run it.
+type Metadata struct {
+	Name        string   `yaml:"name"`
+	Kind        string   `yaml:"kind"`
+	Description string   `yaml:"description"`
+	Version     string   `yaml:"version"`
+	Command     string   `yaml:"command"`
+	Args        []string `yaml:"args"`
+}
+
+// Plugin is a discovered, on-disk plugin.
+type Plugin struct {
+	Metadata
+	Dir string
+}
+
+// FindPlugins scans each of the given directories for immediate
+// subdirectories containing a plugin.yaml, the same layout Helm's
+// pkg/plugin.FindPlugins uses.
+func FindPlugins(dirs []string) ([]*Plugin, error) {
+	var found []*Plugin
+	for _, dir := range dirs {
+		entries, err := ioutil.ReadDir(dir)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() {
+				continue
+			}
+			pluginDir := filepath.Join(dir, entry.Name())
+			if _, err := os.Stat(filepath.Join(pluginDir, ManifestName)); os.IsNotExist(err) {
+				continue
+			}
+
+			p, err := LoadPlugin(pluginDir)
+			if err != nil {
+				return nil, err
+			}
+			found = append(found, p)
+		}
+	}
+	return found, nil
+}
+
+// LoadPlugin reads and parses the plugin.yaml in the given directory.
+func LoadPlugin(dir string) (*Plugin, error) {
+	manifestPath := filepath.Join(dir, ManifestName)
+	raw, err := ioutil.ReadFile(manifestPath)
+	if os.IsNotExist(err) {
+		return nil, MissingManifestError(dir)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var meta Metadata
+	if err := yaml.Unmarshal(raw, &meta); err != nil {
+		return nil, InvalidManifestError(dir, err)
+	}
+
+	return &Plugin{Metadata: meta, Dir: dir}, nil
+}
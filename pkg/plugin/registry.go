@@ -0,0 +1,174 @@
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	errors "github.com/rotisserie/eris"
+)
+
+// ManifestSource renders manifests for an InstallationSpec kind that isn't
+// one of the built-in Github/Helm/Archive/Steps handlers, e.g. "kustomize",
+// "jsonnet", or "oci". In-process implementations are registered directly;
+// out-of-process plugins are adapted onto this interface by execSource.
+type ManifestSource interface {
+	// Kind is the InstallationSpec variant this source handles.
+	Kind() string
+	// GetManifests renders the raw spec payload into a YAML manifest bundle.
+	GetManifests(ctx context.Context, rawSpec []byte) ([]byte, error)
+}
+
+// ValueTransformer mutates a coalesced values YAML document before it's
+// handed to the template/render stage, e.g. to resolve secret references.
+type ValueTransformer interface {
+	// Name identifies the transformer in error messages and is the key the
+	// Registry stores it under.
+	Name() string
+	TransformValues(ctx context.Context, values string) (string, error)
+}
+
+var (
+	UnknownSourceKindError = func(kind string) error {
+		return errors.Errorf("no plugin registered for installation spec kind %v", kind)
+	}
+)
+
+// Registry is the set of manifest sources and value transformers available
+// at runtime, populated from in-process registrations and discovered
+// on-disk plugins. Safe for concurrent use: DefaultRegistry is registered
+// into and read from the CLI, controller, and tests, often concurrently.
+// Construct one with NewRegistry: a zero Registry has no maps, and
+// registering into it panics.
+type Registry struct {
+	mu           sync.RWMutex
+	sources      map[string]ManifestSource
+	transformers map[string]ValueTransformer
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		sources:      make(map[string]ManifestSource),
+		transformers: make(map[string]ValueTransformer),
+	}
+}
+
+// DefaultRegistry is the process-wide registry pkg/render consults to render
+// a PluginInstallationSpec by its Kind, and for the value transformers
+// ComputeValueOverrides applies.
+var DefaultRegistry = NewRegistry()
+
+// RegisterSource adds an in-process ManifestSource to the registry.
+func (r *Registry) RegisterSource(source ManifestSource) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sources[source.Kind()] = source
+}
+
+// RegisterTransformer adds an in-process ValueTransformer to the registry.
+func (r *Registry) RegisterTransformer(transformer ValueTransformer) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.transformers[transformer.Name()] = transformer
+}
+
+// Source looks up a ManifestSource by InstallationSpec kind.
+func (r *Registry) Source(kind string) (ManifestSource, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	source, ok := r.sources[kind]
+	return source, ok
+}
+
+// Transformers returns every registered ValueTransformer, sorted by Name so
+// that a pipeline with more than one transformer applies them in a stable,
+// repeatable order.
+func (r *Registry) Transformers() []ValueTransformer {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.transformers))
+	for name := range r.transformers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	transformers := make([]ValueTransformer, 0, len(names))
+	for _, name := range names {
+		transformers = append(transformers, r.transformers[name])
+	}
+	return transformers
+}
+
+// LoadDiscovered finds plugins under dirs and registers an out-of-process
+// ManifestSource for each one, keyed by its declared Kind. Discovered
+// plugins are never registered as ValueTransformers; those are only added
+// in-process through RegisterTransformer.
+func (r *Registry) LoadDiscovered(dirs []string) error {
+	plugins, err := FindPlugins(dirs)
+	if err != nil {
+		return err
+	}
+	for _, p := range plugins {
+		r.RegisterSource(&execSource{Plugin: p})
+	}
+	return nil
+}
+
+// DefaultDirs is where meshctl installs plugins (`meshctl plugin install`)
+// and where EnsureDiscovered looks for them by default.
+func DefaultDirs() []string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil
+	}
+	return []string{filepath.Join(home, ".meshctl", "plugins")}
+}
+
+var (
+	discoverOnce sync.Once
+	discoverErr  error
+)
+
+// EnsureDiscovered loads on-disk plugins from dirs into DefaultRegistry the
+// first time it's called in this process; later calls are no-ops and
+// return the first call's result. render.renderStages calls this so a
+// plugin dropped into DefaultDirs() is actually usable as an
+// InstallationSpec source without any separate wiring.
+func EnsureDiscovered(dirs []string) error {
+	discoverOnce.Do(func() {
+		discoverErr = DefaultRegistry.LoadDiscovered(dirs)
+	})
+	return discoverErr
+}
+
+// execSource adapts an on-disk plugin to ManifestSource by shelling out to
+// its declared Command with the raw spec payload on stdin, the same
+// convention Helm's --post-renderer plugins use.
+type execSource struct {
+	*Plugin
+}
+
+func (s *execSource) Kind() string {
+	return s.Metadata.Kind
+}
+
+func (s *execSource) GetManifests(ctx context.Context, rawSpec []byte) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, s.Metadata.Command, s.Metadata.Args...)
+	cmd.Dir = s.Dir
+	cmd.Stdin = bytes.NewReader(rawSpec)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, errors.Wrapf(err, "plugin %v failed: %v", s.Metadata.Name, stderr.String())
+	}
+	return stdout.Bytes(), nil
+}
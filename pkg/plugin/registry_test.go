@@ -0,0 +1,82 @@
+package plugin
+
+import (
+	"context"
+	"sync"
+	"testing"
+)
+
+type fakeTransformer struct {
+	name string
+}
+
+func (f *fakeTransformer) Name() string { return f.name }
+
+func (f *fakeTransformer) TransformValues(ctx context.Context, values string) (string, error) {
+	return values, nil
+}
+
+func TestTransformersAreSortedByName(t *testing.T) {
+	registry := NewRegistry()
+	registry.RegisterTransformer(&fakeTransformer{name: "zeta"})
+	registry.RegisterTransformer(&fakeTransformer{name: "alpha"})
+	registry.RegisterTransformer(&fakeTransformer{name: "mu"})
+
+	var got []string
+	for _, transformer := range registry.Transformers() {
+		got = append(got, transformer.Name())
+	}
+
+	want := []string{"alpha", "mu", "zeta"}
+	if len(got) != len(want) {
+		t.Fatalf("Transformers() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Transformers() = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestRegistryConcurrentAccessDoesNotRace(t *testing.T) {
+	registry := NewRegistry()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		name := string(rune('a' + i))
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			registry.RegisterTransformer(&fakeTransformer{name: name})
+		}()
+		go func() {
+			defer wg.Done()
+			registry.Transformers()
+		}()
+	}
+	wg.Wait()
+
+	if len(registry.Transformers()) != 10 {
+		t.Fatalf("Transformers() = %v, want 10 registered transformers", registry.Transformers())
+	}
+}
+
+func TestEnsureDiscoveredSucceedsOnRepeatCalls(t *testing.T) {
+	discoverOnce = sync.Once{}
+	discoverErr = nil
+	defer func() {
+		discoverOnce = sync.Once{}
+		discoverErr = nil
+	}()
+
+	dir := t.TempDir()
+	if err := EnsureDiscovered([]string{dir}); err != nil {
+		t.Fatalf("EnsureDiscovered() error = %v", err)
+	}
+
+	// A second call must not re-run discovery; it should return the same
+	// (nil) result without erroring even if dirs changes.
+	if err := EnsureDiscovered([]string{"/does/not/exist"}); err != nil {
+		t.Fatalf("EnsureDiscovered() second call error = %v", err)
+	}
+}
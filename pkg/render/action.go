@@ -0,0 +1,243 @@
+package render
+
+import (
+	"encoding/json"
+	"time"
+
+	"context"
+
+	errors "github.com/rotisserie/eris"
+	"github.com/solo-io/go-utils/contextutils"
+	"github.com/solo-io/go-utils/installutils/kuberesource"
+	hubv1 "github.com/solo-io/service-mesh-hub/api/v1"
+	"github.com/solo-io/service-mesh-hub/pkg/plugin"
+	"github.com/solo-io/service-mesh-hub/pkg/render/validation"
+	"go.uber.org/zap"
+)
+
+// OutputFormat selects how Template serializes the resources it renders.
+type OutputFormat string
+
+const (
+	OutputFormatYAML OutputFormat = "yaml"
+	OutputFormatJSON OutputFormat = "json"
+)
+
+// Options are the knobs shared by every render action. Install, Template,
+// Validate, and Lint each embed Options rather than repeating these fields,
+// so a caller configures all of them the same way.
+type Options struct {
+	// DryRun renders manifests without taking any further action.
+	DryRun bool
+	// KubeVersion pins the Kubernetes version reported to helm chart
+	// rendering, mirroring the --kube-version flag Helm exposes on its own
+	// actions. ValidateInputs does not use it.
+	KubeVersion string
+	// Atomic indicates a failed Install should roll back rather than leave
+	// partial resources behind.
+	Atomic bool
+	// Timeout bounds how long an action is allowed to run.
+	Timeout time.Duration
+	// OutputFormat selects how Template serializes its result.
+	OutputFormat OutputFormat
+	// Validate decides which resource-dependency checks ValidateInputs runs.
+	// Defaults to validation.NoopValidateResources.
+	Validate validation.ValidateResourceDependencies
+}
+
+func defaultOptions() Options {
+	return Options{
+		OutputFormat: OutputFormatYAML,
+		Validate:     validation.NoopValidateResources,
+	}
+}
+
+// withActionTimeout bounds ctx by opts.Timeout, the way every action's Run
+// method honors Options.Timeout. A zero Timeout leaves ctx unbounded.
+func withActionTimeout(ctx context.Context, opts Options) (context.Context, context.CancelFunc) {
+	if opts.Timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, opts.Timeout)
+}
+
+// ApplyNotImplementedError is returned by Install.Run when DryRun is false:
+// this package can render and validate manifests, but doesn't yet carry a
+// Kubernetes client capable of applying them.
+var ApplyNotImplementedError = errors.Errorf("applying rendered manifests to a cluster is not yet implemented; run with DryRun to render only")
+
+// renderStages runs the portion of the pipeline shared by every action:
+// input validation, template execution, manifest fetch, and layer
+// coalescing. Actions compose it with whatever comes after (label
+// filtering, an eventual apply step, lint bookkeeping, ...).
+func renderStages(ctx context.Context, opts Options, inputs ValuesInputs, spec *hubv1.VersionedApplicationSpec) (kuberesource.UnstructuredResources, error) {
+	if err := plugin.EnsureDiscovered(plugin.DefaultDirs()); err != nil {
+		return nil, errors.Wrap(err, "discovering installed plugins")
+	}
+
+	if err := ValidateInputs(inputs, *spec, opts.Validate); err != nil {
+		return nil, err
+	}
+
+	inputs.KubeVersion = opts.KubeVersion
+
+	inputs, err := ExecInputValuesTemplates(ctx, inputs, spec)
+	if err != nil {
+		return nil, FailedRenderValueTemplatesError(err)
+	}
+
+	manifests, err := GetManifestsFromApplicationSpec(ctx, inputs, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return ApplyLayers(ctx, inputs, manifests)
+}
+
+// Template renders the manifests for an application spec without installing
+// them, analogous to `helm template`.
+type Template struct {
+	Options
+}
+
+// NewTemplate constructs a Template action with its default options.
+func NewTemplate() *Template {
+	return &Template{Options: defaultOptions()}
+}
+
+func (t *Template) Run(ctx context.Context, inputs ValuesInputs, spec *hubv1.VersionedApplicationSpec) (kuberesource.UnstructuredResources, error) {
+	ctx, cancel := withActionTimeout(ctx, t.Options)
+	defer cancel()
+
+	resources, err := renderStages(ctx, t.Options, inputs, spec)
+	if err != nil {
+		return nil, err
+	}
+	resources = FilterByLabel(ctx, spec, resources)
+	return applyPostRenderers(ctx, resources, inputs, spec)
+}
+
+// Render runs Run and serializes the result according to Options.OutputFormat.
+func (t *Template) Render(ctx context.Context, inputs ValuesInputs, spec *hubv1.VersionedApplicationSpec) (string, error) {
+	resources, err := t.Run(ctx, inputs, spec)
+	if err != nil {
+		return "", err
+	}
+
+	switch t.Options.OutputFormat {
+	case OutputFormatJSON:
+		return resourcesToJSON(resources)
+	default:
+		return resources.ToYaml()
+	}
+}
+
+// resourcesToJSON serializes resources as a JSON array, for
+// Options.OutputFormat == OutputFormatJSON.
+func resourcesToJSON(resources kuberesource.UnstructuredResources) (string, error) {
+	objects := make([]map[string]interface{}, len(resources))
+	for i, resource := range resources {
+		objects[i] = resource.Object
+	}
+
+	out, err := json.Marshal(objects)
+	if err != nil {
+		return "", errors.Wrapf(err, "error marshalling resources as json")
+	}
+	return string(out), nil
+}
+
+// Install renders the manifests for an application spec and, with DryRun
+// set, returns them the same way Template does. Without DryRun, Install
+// doesn't yet carry a Kubernetes client to apply the rendered resources, so
+// Run returns ApplyNotImplementedError after logging Atomic and Timeout for
+// whoever's watching.
+type Install struct {
+	Options
+}
+
+// NewInstall constructs an Install action with its default options.
+func NewInstall() *Install {
+	return &Install{Options: defaultOptions()}
+}
+
+func (i *Install) Run(ctx context.Context, inputs ValuesInputs, spec *hubv1.VersionedApplicationSpec) (kuberesource.UnstructuredResources, error) {
+	template := &Template{Options: i.Options}
+	resources, err := template.Run(ctx, inputs, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	if i.Options.DryRun {
+		return resources, nil
+	}
+
+	contextutils.LoggerFrom(ctx).Errorw(ApplyNotImplementedError.Error(),
+		zap.Bool("atomic", i.Options.Atomic),
+		zap.Duration("timeout", i.Options.Timeout))
+	return nil, ApplyNotImplementedError
+}
+
+// Validate checks that the given inputs satisfy the application spec
+// (required layers, layer options, and parameters) without rendering any
+// manifests.
+type Validate struct {
+	Options
+}
+
+// NewValidate constructs a Validate action with its default options.
+func NewValidate() *Validate {
+	return &Validate{Options: defaultOptions()}
+}
+
+func (v *Validate) Run(ctx context.Context, inputs ValuesInputs, spec *hubv1.VersionedApplicationSpec) error {
+	ctx, cancel := withActionTimeout(ctx, v.Options)
+	defer cancel()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return ValidateInputs(inputs, *spec, v.Options.Validate)
+}
+
+// LintResult collects the problems found while linting an application spec.
+type LintResult struct {
+	Errors []error
+}
+
+// HasErrors reports whether the lint found any problems.
+func (r LintResult) HasErrors() bool {
+	return len(r.Errors) > 0
+}
+
+// Lint runs Validate and then Template against an application spec,
+// reporting failures in a LintResult rather than as an error, similar to
+// `helm lint`. Template is not run when Validate fails, so the result holds
+// at most one error.
+type Lint struct {
+	Options
+}
+
+// NewLint constructs a Lint action with its default options.
+func NewLint() *Lint {
+	return &Lint{Options: defaultOptions()}
+}
+
+func (l *Lint) Run(ctx context.Context, inputs ValuesInputs, spec *hubv1.VersionedApplicationSpec) LintResult {
+	ctx, cancel := withActionTimeout(ctx, l.Options)
+	defer cancel()
+
+	var result LintResult
+
+	validate := &Validate{Options: l.Options}
+	if err := validate.Run(ctx, inputs, spec); err != nil {
+		result.Errors = append(result.Errors, err)
+		return result
+	}
+
+	template := &Template{Options: l.Options}
+	if _, err := template.Run(ctx, inputs, spec); err != nil {
+		result.Errors = append(result.Errors, err)
+	}
+	return result
+}
@@ -0,0 +1,43 @@
+package render
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/solo-io/go-utils/installutils/kuberesource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestWithActionTimeoutZeroLeavesContextUnbounded(t *testing.T) {
+	ctx, cancel := withActionTimeout(context.Background(), Options{})
+	defer cancel()
+
+	if _, ok := ctx.Deadline(); ok {
+		t.Fatal("withActionTimeout() with a zero Timeout set a deadline, want none")
+	}
+}
+
+func TestWithActionTimeoutSetsDeadline(t *testing.T) {
+	ctx, cancel := withActionTimeout(context.Background(), Options{Timeout: time.Minute})
+	defer cancel()
+
+	if _, ok := ctx.Deadline(); !ok {
+		t.Fatal("withActionTimeout() with a nonzero Timeout set no deadline")
+	}
+}
+
+func TestResourcesToJSON(t *testing.T) {
+	resource := &unstructured.Unstructured{}
+	resource.SetAPIVersion("v1")
+	resource.SetKind("ConfigMap")
+	resource.SetName("config")
+
+	out, err := resourcesToJSON(kuberesource.UnstructuredResources{resource})
+	if err != nil {
+		t.Fatalf("resourcesToJSON() error = %v", err)
+	}
+	if out == "" {
+		t.Fatal("resourcesToJSON() = \"\", want a non-empty JSON array")
+	}
+}
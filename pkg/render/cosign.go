@@ -0,0 +1,27 @@
+package render
+
+import (
+	"context"
+	"crypto"
+
+	errors "github.com/rotisserie/eris"
+	"github.com/sigstore/cosign/pkg/cosign"
+	"github.com/sigstore/sigstore/pkg/signature"
+)
+
+// cosignVerify checks artifactPath against a cosign signature produced for
+// the given PEM-encoded public key, the same check `cosign verify --key`
+// performs on the command line.
+func cosignVerify(artifactPath, publicKeyPEM string) error {
+	verifier, err := signature.LoadPublicKeyRaw([]byte(publicKeyPEM), crypto.SHA256)
+	if err != nil {
+		return errors.Wrapf(err, "loading cosign public key")
+	}
+
+	if _, err := cosign.VerifyLocalImageSignatures(context.Background(), artifactPath, &cosign.CheckOpts{
+		SigVerifier: verifier,
+	}); err != nil {
+		return errors.Wrapf(err, "chart did not pass cosign verification")
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package render
+
+import (
+	"context"
+
+	errors "github.com/rotisserie/eris"
+)
+
+var (
+	UnknownEnvironmentError = func(name string) error {
+		return errors.Errorf("no environment named %v is defined on this input", name)
+	}
+
+	CyclicEnvironmentBaseError = func(name string) error {
+		return errors.Errorf("environment %v is part of a cycle in its bases", name)
+	}
+)
+
+// Environment is a named, inheritable layer of values, modeled on Helmfile's
+// ReleaseSetSpec.Environments: each environment carries its own values and
+// secret sources and can point at other environments to inherit from via
+// Bases, resolved before any of the existing spec/layer/param/user values.
+type Environment struct {
+	Name string
+	// Bases are the names of other environments in the same Environments set
+	// that this one inherits from. Bases are merged in order, lowest
+	// precedence first, before Defaults and Values are applied.
+	Bases []string
+	// Defaults is a single YAML values document applied before Values.
+	Defaults string
+	// Values is a list of YAML values documents, merged in order with later
+	// entries taking precedence.
+	Values []string
+	// Secrets is a list of YAML values documents whose leaf values may be
+	// vals-style secret references (e.g. vault://secret/creds#apiKey); each
+	// is resolved via the registered SecretResolver before being merged.
+	Secrets []string
+}
+
+// Environments is the named set of Environment layers an application spec's
+// inputs may select from.
+type Environments map[string]*Environment
+
+// resolveEnvironment merges the named environment's base chain, defaults,
+// values, and secrets into a single values map, following the same
+// precedence ComputeValueOverrides uses for everything downstream of it.
+func resolveEnvironment(ctx context.Context, environments Environments, name string) (map[string]interface{}, error) {
+	return resolveEnvironmentRec(ctx, environments, name, map[string]bool{})
+}
+
+func resolveEnvironmentRec(ctx context.Context, environments Environments, name string, visiting map[string]bool) (map[string]interface{}, error) {
+	env, ok := environments[name]
+	if !ok {
+		return nil, UnknownEnvironmentError(name)
+	}
+	if visiting[name] {
+		return nil, CyclicEnvironmentBaseError(name)
+	}
+	visiting[name] = true
+	defer delete(visiting, name)
+
+	valuesMap := make(map[string]interface{})
+	for _, base := range env.Bases {
+		baseMap, err := resolveEnvironmentRec(ctx, environments, base, visiting)
+		if err != nil {
+			return nil, err
+		}
+		valuesMap = CoalesceValuesMap(ctx, valuesMap, baseMap)
+	}
+
+	defaultsMap, err := ConvertYamlStringToNestedMap(env.Defaults)
+	if err != nil {
+		return nil, err
+	}
+	valuesMap = CoalesceValuesMap(ctx, valuesMap, defaultsMap)
+
+	for _, values := range env.Values {
+		m, err := ConvertYamlStringToNestedMap(values)
+		if err != nil {
+			return nil, err
+		}
+		valuesMap = CoalesceValuesMap(ctx, valuesMap, m)
+	}
+
+	for _, secrets := range env.Secrets {
+		m, err := ConvertYamlStringToNestedMap(secrets)
+		if err != nil {
+			return nil, err
+		}
+		resolved, err := resolveSecretRefs(ctx, m)
+		if err != nil {
+			return nil, err
+		}
+		valuesMap = CoalesceValuesMap(ctx, valuesMap, resolved)
+	}
+
+	return valuesMap, nil
+}
@@ -0,0 +1,90 @@
+package render
+
+import (
+	"context"
+	"testing"
+)
+
+func TestResolveEnvironmentSharedBaseIsNotACycle(t *testing.T) {
+	environments := Environments{
+		"common": {
+			Name:   "common",
+			Values: []string{"region: us-east-1"},
+		},
+		"staging": {
+			Name:   "staging",
+			Bases:  []string{"common"},
+			Values: []string{"replicas: 1"},
+		},
+		"staging-eu": {
+			Name:   "staging-eu",
+			Bases:  []string{"common"},
+			Values: []string{"replicas: 2"},
+		},
+	}
+
+	if _, err := resolveEnvironment(context.Background(), environments, "staging"); err != nil {
+		t.Fatalf("resolveEnvironment(staging) error = %v", err)
+	}
+	if _, err := resolveEnvironment(context.Background(), environments, "staging-eu"); err != nil {
+		t.Fatalf("resolveEnvironment(staging-eu) error = %v, want nil (common is a shared base, not a cycle)", err)
+	}
+}
+
+func TestResolveEnvironmentMergesBasesDefaultsAndValuesInPrecedenceOrder(t *testing.T) {
+	environments := Environments{
+		"base": {
+			Name:   "base",
+			Values: []string{"replicas: 1\nregion: us-east-1"},
+		},
+		"prod": {
+			Name:     "prod",
+			Bases:    []string{"base"},
+			Defaults: "replicas: 2",
+			Values:   []string{"replicas: 3"},
+		},
+	}
+
+	resolved, err := resolveEnvironment(context.Background(), environments, "prod")
+	if err != nil {
+		t.Fatalf("resolveEnvironment(prod) error = %v", err)
+	}
+
+	if resolved["region"] != "us-east-1" {
+		t.Fatalf("resolveEnvironment(prod)[region] = %v, want it inherited from base", resolved["region"])
+	}
+	if resolved["replicas"] != float64(3) {
+		t.Fatalf("resolveEnvironment(prod)[replicas] = %v, want the later Values entry to win", resolved["replicas"])
+	}
+}
+
+func TestResolveEnvironmentResolvesSecretRefs(t *testing.T) {
+	RegisterSecretResolver(&fakeSecretResolver{scheme: "vault"})
+
+	environments := Environments{
+		"prod": {
+			Name:    "prod",
+			Secrets: []string{"apiKey: vault://secret/creds#apiKey"},
+		},
+	}
+
+	resolved, err := resolveEnvironment(context.Background(), environments, "prod")
+	if err != nil {
+		t.Fatalf("resolveEnvironment(prod) error = %v", err)
+	}
+	if resolved["apiKey"] != "resolved:vault://secret/creds#apiKey" {
+		t.Fatalf("resolveEnvironment(prod)[apiKey] = %v, want the secret ref resolved", resolved["apiKey"])
+	}
+}
+
+func TestResolveEnvironmentDetectsGenuineCycle(t *testing.T) {
+	environments := Environments{
+		"a": {Name: "a", Bases: []string{"b"}},
+		"b": {Name: "b", Bases: []string{"a"}},
+	}
+
+	_, err := resolveEnvironment(context.Background(), environments, "a")
+	if err == nil {
+		t.Fatal("resolveEnvironment(a) error = nil, want a cyclic base error")
+	}
+}
@@ -1,9 +1,7 @@
 package render
 
 import (
-	"bytes"
 	"context"
-	"text/template"
 
 	"github.com/solo-io/service-mesh-hub/pkg/render/validation"
 	"github.com/solo-io/solo-kit/pkg/api/v1/resources/core"
@@ -14,6 +12,7 @@ import (
 	"github.com/solo-io/go-utils/installutils/helmchart"
 	"github.com/solo-io/go-utils/installutils/kuberesource"
 	hubv1 "github.com/solo-io/service-mesh-hub/api/v1"
+	"github.com/solo-io/service-mesh-hub/pkg/plugin"
 	"go.uber.org/zap"
 )
 
@@ -63,14 +62,32 @@ type ValuesInputs struct {
 	Flavor           *hubv1.Flavor
 	Layers           []LayerInput
 	MeshRef          core.ResourceRef
+	// MeshType and MeshDiscoveredVersion feed the .Mesh template context;
+	// IsUpgrade feeds .Release.IsUpgrade.
+	MeshType              string
+	MeshDiscoveredVersion string
+	IsUpgrade             bool
+	// KubeVersion pins the capabilities reported to helm chart rendering,
+	// set from Options.KubeVersion by renderStages.
+	KubeVersion string
 
 	UserDefinedValues string
 	SpecDefinedValues string
 	// These map to the params found on versions, flavors, and layers,
 	Params map[string]string
+
+	// Environments are the named value-inheritance layers this input can
+	// select from; SelectedEnvironment picks which one, if any, seeds
+	// ComputeValueOverrides ahead of the spec/layer/param/user values.
+	Environments        Environments
+	SelectedEnvironment string
+
+	// PostRenderers run, in order, after the spec's own postRender:
+	// pipeline, against the final filtered resource set.
+	PostRenderers []Transformer
 }
 
-// Deprecated: use ManifestRenderer.ComputeResourcesForApplication
+// Deprecated: use Template.Run or Install.Run.
 func ComputeResourcesForApplication(ctx context.Context, inputs ValuesInputs, spec *hubv1.VersionedApplicationSpec) (kuberesource.UnstructuredResources, error) {
 	renderer := NewManifestRenderer(validation.NoopValidateResources)
 	return renderer.ComputeResourcesForApplication(ctx, inputs, spec)
@@ -141,6 +158,14 @@ func ValidateInputs(inputs ValuesInputs, spec hubv1.VersionedApplicationSpec, va
 func ComputeValueOverrides(ctx context.Context, inputs ValuesInputs) (string, error) {
 	valuesMap := make(map[string]interface{})
 
+	if inputs.SelectedEnvironment != "" {
+		environmentValues, err := resolveEnvironment(ctx, inputs.Environments, inputs.SelectedEnvironment)
+		if err != nil {
+			return "", err
+		}
+		valuesMap = CoalesceValuesMap(ctx, valuesMap, environmentValues)
+	}
+
 	specValues, err := ConvertYamlStringToNestedMap(inputs.SpecDefinedValues)
 	if err != nil {
 		contextutils.LoggerFrom(ctx).Errorw("Error parsing spec values yaml",
@@ -183,6 +208,10 @@ func ComputeValueOverrides(ctx context.Context, inputs ValuesInputs) (string, er
 			zap.Any("params", inputs.UserDefinedValues))
 		return "", err
 	}
+	userValues, err = resolveSecretRefs(ctx, userValues)
+	if err != nil {
+		return "", err
+	}
 	valuesMap = CoalesceValuesMap(ctx, valuesMap, userValues)
 
 	values, err := ConvertNestedMapToYaml(valuesMap)
@@ -190,6 +219,14 @@ func ComputeValueOverrides(ctx context.Context, inputs ValuesInputs) (string, er
 		contextutils.LoggerFrom(ctx).Errorw(err.Error(), zap.Error(err), zap.Any("valuesMap", valuesMap))
 		return "", err
 	}
+
+	for _, transformer := range plugin.DefaultRegistry.Transformers() {
+		values, err = transformer.TransformValues(ctx, values)
+		if err != nil {
+			return "", errors.Wrapf(err, "value transformer %v failed", transformer.Name())
+		}
+	}
+
 	return values, nil
 }
 
@@ -220,6 +257,18 @@ func GetManifestsFromApplicationSpec(ctx context.Context, inputs ValuesInputs, s
 			return nil, err
 		}
 		manifests = archiveManifests
+	case *hubv1.VersionedApplicationSpec_PluginSpec:
+		pluginManifests, err := getManifestsFromPlugin(ctx, installationSpec.PluginSpec, inputs)
+		if err != nil {
+			return nil, err
+		}
+		manifests = pluginManifests
+	case *hubv1.VersionedApplicationSpec_OciChart:
+		ociManifests, err := getManifestsFromOci(ctx, installationSpec.OciChart, inputs)
+		if err != nil {
+			return nil, err
+		}
+		manifests = ociManifests
 	default:
 		return nil, MissingInstallSpecError
 	}
@@ -227,6 +276,30 @@ func GetManifestsFromApplicationSpec(ctx context.Context, inputs ValuesInputs, s
 	return manifests, nil
 }
 
+// getManifestsFromPlugin dispatches to whichever ManifestSource was
+// registered (in-process, or discovered on disk) for the spec's Kind. This
+// is how third-party InstallationSpec variants (kustomize, jsonnet, oci://
+// charts, ...) are plugged in without this package knowing about them.
+func getManifestsFromPlugin(ctx context.Context, pluginSpec *hubv1.PluginInstallationSpec, inputs ValuesInputs) (helmchart.Manifests, error) {
+	source, ok := plugin.DefaultRegistry.Source(pluginSpec.GetKind())
+	if !ok {
+		return nil, plugin.UnknownSourceKindError(pluginSpec.GetKind())
+	}
+
+	rendered, err := source.GetManifests(ctx, []byte(pluginSpec.GetConfig()))
+	if err != nil {
+		wrapped := FailedToRenderManifestsError(err)
+		contextutils.LoggerFrom(ctx).Errorw(wrapped.Error(),
+			zap.Error(err),
+			zap.String("kind", pluginSpec.GetKind()),
+			zap.String("releaseName", inputs.Name),
+			zap.String("namespace", inputs.InstallNamespace))
+		return nil, wrapped
+	}
+
+	return installutils.GetManifestsFromBytes(rendered)
+}
+
 func FilterByLabel(ctx context.Context, spec *hubv1.VersionedApplicationSpec, resources kuberesource.UnstructuredResources) kuberesource.UnstructuredResources {
 	labels := spec.GetRequiredLabels()
 	if len(labels) > 0 {
@@ -247,7 +320,7 @@ func getManifestsFromHelm(ctx context.Context, helmInstallSpec *hubv1.TgzLocatio
 		values,
 		inputs.Name,
 		inputs.InstallNamespace,
-		"")
+		inputs.KubeVersion)
 	if err != nil {
 		wrapped := FailedToRenderManifestsError(err)
 		contextutils.LoggerFrom(ctx).Errorw(wrapped.Error(),
@@ -256,7 +329,7 @@ func getManifestsFromHelm(ctx context.Context, helmInstallSpec *hubv1.TgzLocatio
 			zap.String("values", values),
 			zap.String("releaseName", inputs.Name),
 			zap.String("namespace", inputs.InstallNamespace),
-			zap.String("kubeVersion", ""))
+			zap.String("kubeVersion", inputs.KubeVersion))
 		return nil, wrapped
 	}
 	return manifests, nil
@@ -277,7 +350,7 @@ func getManifestsFromGithub(ctx context.Context, githubInstallSpec *hubv1.Github
 		values,
 		inputs.Name,
 		inputs.InstallNamespace,
-		"")
+		inputs.KubeVersion)
 	if err != nil {
 		wrapped := FailedToRenderManifestsError(err)
 		contextutils.LoggerFrom(ctx).Errorw(wrapped.Error(),
@@ -286,7 +359,7 @@ func getManifestsFromGithub(ctx context.Context, githubInstallSpec *hubv1.Github
 			zap.String("values", values),
 			zap.String("releaseName", inputs.Name),
 			zap.String("namespace", inputs.InstallNamespace),
-			zap.String("kubeVersion", ""))
+			zap.String("kubeVersion", inputs.KubeVersion))
 		return nil, wrapped
 	}
 	return manifests, nil
@@ -374,6 +447,12 @@ func getManifestsFromInstallationStep(ctx context.Context, inputs ValuesInputs,
 			return nil, err
 		}
 		manifests = archiveManifests
+	case *hubv1.InstallationSteps_Step_PluginSpec:
+		pluginManifests, err := getManifestsFromPlugin(ctx, installationSpec.PluginSpec, inputs)
+		if err != nil {
+			return nil, err
+		}
+		manifests = pluginManifests
 	default:
 		return nil, MissingInstallSpecError
 	}
@@ -381,37 +460,5 @@ func getManifestsFromInstallationStep(ctx context.Context, inputs ValuesInputs,
 	return manifests, nil
 }
 
-// The SpecDefinedValues, UserDefinedValues, and Params inputs can contain template
-// actions (text delimited by "{{" and "}}" ). This function renders the contents of these
-// parameters using the data contained in 'input' and updates 'input' with the results.
-func ExecInputValuesTemplates(inputs ValuesInputs) (ValuesInputs, error) {
-
-	// Render the helm values string that comes from the extension spec
-	buf := new(bytes.Buffer)
-	tpl := template.Must(template.New("specValues").Parse(inputs.SpecDefinedValues))
-	if err := tpl.Execute(buf, inputs); err != nil {
-		return ValuesInputs{}, err
-	}
-	inputs.SpecDefinedValues = buf.String()
-	buf.Reset()
-
-	// Render the helm values string that comes from the user provided overrides
-	tpl = template.Must(template.New("userValues").Parse(inputs.UserDefinedValues))
-	if err := tpl.Execute(buf, inputs); err != nil {
-		return ValuesInputs{}, err
-	}
-	inputs.UserDefinedValues = buf.String()
-	buf.Reset()
-
-	// Render the values of the parameters
-	for paramName, paramValue := range inputs.Params {
-		t := template.Must(template.New(paramName).Parse(paramValue))
-		if err := t.Execute(buf, inputs); err != nil {
-			return ValuesInputs{}, err
-		}
-		inputs.Params[paramName] = buf.String()
-		buf.Reset()
-	}
-
-	return inputs, nil
-}
+// ExecInputValuesTemplates lives in values_template.go; it renders the
+// templates in SpecDefinedValues, UserDefinedValues, and Params.
@@ -0,0 +1,65 @@
+package render
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBearerTokenRoundTripperSetsAuthorizationHeader(t *testing.T) {
+	var observed string
+	rt := &bearerTokenRoundTripper{
+		token: "s3cr3t",
+		next: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			observed = req.Header.Get("Authorization")
+			return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+		}),
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "https://registry.example.com/v2/", nil)
+	if err != nil {
+		t.Fatalf("NewRequest() error = %v", err)
+	}
+
+	if _, err := rt.RoundTrip(req); err != nil {
+		t.Fatalf("RoundTrip() error = %v", err)
+	}
+
+	if want := "Bearer s3cr3t"; observed != want {
+		t.Fatalf("Authorization header = %q, want %q", observed, want)
+	}
+	if req.Header.Get("Authorization") != "" {
+		t.Fatal("RoundTrip() mutated the caller's request instead of a clone")
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+func TestCacheChartTarballReusesExistingFile(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	data := []byte("fake chart tarball bytes")
+
+	first, err := cacheChartTarball(data, "oci://example.com/charts/demo:1.0.0")
+	if err != nil {
+		t.Fatalf("cacheChartTarball() error = %v", err)
+	}
+	if _, err := os.Stat(first); err != nil {
+		t.Fatalf("expected cached tarball at %v: %v", first, err)
+	}
+
+	second, err := cacheChartTarball(data, "oci://example.com/charts/demo:1.0.0")
+	if err != nil {
+		t.Fatalf("cacheChartTarball() second call error = %v", err)
+	}
+	if first != second {
+		t.Fatalf("cacheChartTarball() = %v, want the same cached path %v", second, first)
+	}
+	if filepath.Dir(first) != filepath.Join(home, ociChartCacheDir) {
+		t.Fatalf("cacheChartTarball() wrote outside ociChartCacheDir: %v", first)
+	}
+}
@@ -0,0 +1,133 @@
+package render
+
+import (
+	"context"
+	"encoding/json"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	errors "github.com/rotisserie/eris"
+	"github.com/solo-io/go-utils/installutils/kuberesource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"sigs.k8s.io/yaml"
+)
+
+// NoMatchingResourceError is returned when a patch's selector doesn't match
+// any rendered resource, so a typo'd or stale selector surfaces as a render
+// failure instead of being silently skipped.
+var NoMatchingResourceError = func(selector map[string]interface{}) error {
+	return errors.Errorf("no resource matched patch selector %v", selector)
+}
+
+// StrategicMergePatchTransformer applies kustomize-style strategic-merge
+// patches: each patch is a partial resource document, matched against the
+// resource it targets by apiVersion/kind/metadata.name/metadata.namespace
+// (see findMatchingResource), the convention kustomize's
+// patchesStrategicMerge uses.
+type StrategicMergePatchTransformer struct {
+	// Patches are raw YAML/JSON patch documents.
+	Patches []string
+}
+
+func (t *StrategicMergePatchTransformer) Name() string { return "strategic-merge-patch" }
+
+func (t *StrategicMergePatchTransformer) Transform(_ context.Context, resources kuberesource.UnstructuredResources) (kuberesource.UnstructuredResources, error) {
+	for _, rawPatch := range t.Patches {
+		patch := make(map[string]interface{})
+		if err := yaml.Unmarshal([]byte(rawPatch), &patch); err != nil {
+			return nil, errors.Wrapf(err, "parsing strategic merge patch")
+		}
+
+		target := findMatchingResource(resources, patch)
+		if target == nil {
+			return nil, NoMatchingResourceError(patch)
+		}
+
+		originalJSON, err := json.Marshal(target.Object)
+		if err != nil {
+			return nil, err
+		}
+		patchJSON, err := json.Marshal(patch)
+		if err != nil {
+			return nil, err
+		}
+
+		mergedJSON, err := strategicpatch.StrategicMergePatch(originalJSON, patchJSON, target.Object)
+		if err != nil {
+			return nil, errors.Wrapf(err, "applying strategic merge patch")
+		}
+		if err := json.Unmarshal(mergedJSON, &target.Object); err != nil {
+			return nil, err
+		}
+	}
+	return resources, nil
+}
+
+// JSONPatchTransformer applies an RFC 6902 JSON patch to each resource it
+// targets, matched the same way StrategicMergePatchTransformer matches its
+// patches, so the two can be mixed in a postRender: pipeline.
+type JSONPatchTransformer struct {
+	// Patches maps a target selector document (apiVersion/kind/name/namespace) to the
+	// RFC 6902 JSON patch to apply to it.
+	Patches map[string]string
+}
+
+func (t *JSONPatchTransformer) Name() string { return "json-patch" }
+
+func (t *JSONPatchTransformer) Transform(_ context.Context, resources kuberesource.UnstructuredResources) (kuberesource.UnstructuredResources, error) {
+	for selectorDoc, rawPatch := range t.Patches {
+		selector := make(map[string]interface{})
+		if err := yaml.Unmarshal([]byte(selectorDoc), &selector); err != nil {
+			return nil, errors.Wrapf(err, "parsing json patch target selector")
+		}
+
+		target := findMatchingResource(resources, selector)
+		if target == nil {
+			return nil, NoMatchingResourceError(selector)
+		}
+
+		patch, err := jsonpatch.DecodePatch([]byte(rawPatch))
+		if err != nil {
+			return nil, errors.Wrapf(err, "parsing json patch")
+		}
+
+		originalJSON, err := json.Marshal(target.Object)
+		if err != nil {
+			return nil, err
+		}
+		patchedJSON, err := patch.Apply(originalJSON)
+		if err != nil {
+			return nil, errors.Wrapf(err, "applying json patch")
+		}
+		if err := json.Unmarshal(patchedJSON, &target.Object); err != nil {
+			return nil, err
+		}
+	}
+	return resources, nil
+}
+
+// findMatchingResource returns the first resource whose apiVersion, kind,
+// metadata.name, and metadata.namespace all match the ones set on selector;
+// fields left unset on selector aren't compared. Namespace must be compared
+// alongside name, or a patch meant for one namespace's ConfigMap (say) would
+// silently apply to a same-named ConfigMap in a different namespace.
+func findMatchingResource(resources kuberesource.UnstructuredResources, selector map[string]interface{}) *unstructured.Unstructured {
+	selectorResource := &unstructured.Unstructured{Object: selector}
+
+	for _, resource := range resources {
+		if selectorMatches(selectorResource.GetAPIVersion(), resource.GetAPIVersion()) &&
+			selectorMatches(selectorResource.GetKind(), resource.GetKind()) &&
+			selectorMatches(selectorResource.GetName(), resource.GetName()) &&
+			selectorMatches(selectorResource.GetNamespace(), resource.GetNamespace()) {
+			return resource
+		}
+	}
+	return nil
+}
+
+func selectorMatches(expected, actual string) bool {
+	if expected == "" {
+		return true
+	}
+	return expected == actual
+}
@@ -0,0 +1,112 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/solo-io/go-utils/installutils/kuberesource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func configMap(namespace, name string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion("v1")
+	u.SetKind("ConfigMap")
+	u.SetNamespace(namespace)
+	u.SetName(name)
+	return u
+}
+
+func TestFindMatchingResourceComparesNamespace(t *testing.T) {
+	resources := kuberesource.UnstructuredResources{
+		configMap("ns-a", "config"),
+		configMap("ns-b", "config"),
+	}
+
+	selector := map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]interface{}{
+			"name":      "config",
+			"namespace": "ns-b",
+		},
+	}
+
+	match := findMatchingResource(resources, selector)
+	if match == nil || match.GetNamespace() != "ns-b" {
+		t.Fatalf("findMatchingResource() = %v, want the config map in ns-b", match)
+	}
+}
+
+func TestStrategicMergePatchTransformerErrorsOnNoMatch(t *testing.T) {
+	resources := kuberesource.UnstructuredResources{configMap("ns-a", "config")}
+
+	transformer := &StrategicMergePatchTransformer{
+		Patches: []string{`{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "does-not-exist"}}`},
+	}
+
+	if _, err := transformer.Transform(nil, resources); err == nil {
+		t.Fatal("Transform() error = nil, want an error for an unmatched patch selector")
+	}
+}
+
+func TestStrategicMergePatchTransformerMergesMatchingResource(t *testing.T) {
+	resource := configMap("ns-a", "config")
+	resource.Object["data"] = map[string]interface{}{"existing": "keep"}
+	resources := kuberesource.UnstructuredResources{resource}
+
+	transformer := &StrategicMergePatchTransformer{
+		Patches: []string{`{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "config", "namespace": "ns-a"}, "data": {"added": "value"}}`},
+	}
+
+	got, err := transformer.Transform(nil, resources)
+	if err != nil {
+		t.Fatalf("Transform() error = %v", err)
+	}
+
+	data, found, err := unstructured.NestedStringMap(got[0].Object, "data")
+	if err != nil || !found {
+		t.Fatalf("Transform() data = %v, found = %v, err = %v", data, found, err)
+	}
+	if data["existing"] != "keep" || data["added"] != "value" {
+		t.Fatalf("Transform() merged data = %v, want both existing and added keys", data)
+	}
+}
+
+func TestJSONPatchTransformerAppliesMatchingResource(t *testing.T) {
+	resource := configMap("ns-a", "config")
+	resource.Object["data"] = map[string]interface{}{"existing": "keep"}
+	resources := kuberesource.UnstructuredResources{resource}
+
+	transformer := &JSONPatchTransformer{
+		Patches: map[string]string{
+			`{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "config", "namespace": "ns-a"}}`: `[{"op": "add", "path": "/data/added", "value": "value"}]`,
+		},
+	}
+
+	got, err := transformer.Transform(nil, resources)
+	if err != nil {
+		t.Fatalf("Transform() error = %v", err)
+	}
+
+	data, found, err := unstructured.NestedStringMap(got[0].Object, "data")
+	if err != nil || !found {
+		t.Fatalf("Transform() data = %v, found = %v, err = %v", data, found, err)
+	}
+	if data["existing"] != "keep" || data["added"] != "value" {
+		t.Fatalf("Transform() patched data = %v, want both existing and added keys", data)
+	}
+}
+
+func TestJSONPatchTransformerErrorsOnNoMatch(t *testing.T) {
+	resources := kuberesource.UnstructuredResources{configMap("ns-a", "config")}
+
+	transformer := &JSONPatchTransformer{
+		Patches: map[string]string{
+			`{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "does-not-exist"}}`: `[]`,
+		},
+	}
+
+	if _, err := transformer.Transform(nil, resources); err == nil {
+		t.Fatal("Transform() error = nil, want an error for an unmatched patch selector")
+	}
+}
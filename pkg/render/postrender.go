@@ -0,0 +1,212 @@
+package render
+
+import (
+	"bytes"
+	"context"
+	"os/exec"
+
+	errors "github.com/rotisserie/eris"
+	"github.com/solo-io/go-utils/installutils/kuberesource"
+	hubv1 "github.com/solo-io/service-mesh-hub/api/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+var (
+	FailedToTransformResourcesError = func(name string, err error) error {
+		return errors.Wrapf(err, "post-render transformer %v failed", name)
+	}
+)
+
+// Transformer mutates the resources rendered for an application, after
+// label filtering, the way Helm's --post-renderer flag does for a whole
+// manifest. ValuesInputs.PostRenderers and a spec's postRender: section are
+// both pipelines of Transformer.
+type Transformer interface {
+	// Name identifies the transformer, for error messages and logging.
+	Name() string
+	Transform(ctx context.Context, resources kuberesource.UnstructuredResources) (kuberesource.UnstructuredResources, error)
+}
+
+// applyPostRenderers runs the spec's own postRender: pipeline followed by
+// any caller-supplied ValuesInputs.PostRenderers, in order, against
+// resources.
+func applyPostRenderers(ctx context.Context, resources kuberesource.UnstructuredResources, inputs ValuesInputs, spec *hubv1.VersionedApplicationSpec) (kuberesource.UnstructuredResources, error) {
+	specTransformers, err := postRenderersFromSpec(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	pipeline := append(specTransformers, inputs.PostRenderers...)
+	for _, transformer := range pipeline {
+		resources, err = transformer.Transform(ctx, resources)
+		if err != nil {
+			return nil, FailedToTransformResourcesError(transformer.Name(), err)
+		}
+	}
+	return resources, nil
+}
+
+// postRenderersFromSpec builds the Transformer pipeline declared on the
+// application spec's postRender: section.
+func postRenderersFromSpec(spec *hubv1.VersionedApplicationSpec) ([]Transformer, error) {
+	var transformers []Transformer
+	for _, declared := range spec.GetPostRenderers() {
+		transformer, err := transformerFromProto(declared)
+		if err != nil {
+			return nil, err
+		}
+		transformers = append(transformers, transformer)
+	}
+	return transformers, nil
+}
+
+func transformerFromProto(declared *hubv1.PostRenderer) (Transformer, error) {
+	switch kind := declared.GetKind().(type) {
+	case *hubv1.PostRenderer_LabelsAndAnnotations:
+		return &LabelAnnotationTransformer{
+			Labels:      kind.LabelsAndAnnotations.GetLabels(),
+			Annotations: kind.LabelsAndAnnotations.GetAnnotations(),
+		}, nil
+	case *hubv1.PostRenderer_ImageTagRewrite:
+		return &ImageTagRewriteTransformer{Rewrites: kind.ImageTagRewrite.GetRewrites()}, nil
+	case *hubv1.PostRenderer_NamespacePin:
+		return &NamespaceTransformer{Namespace: kind.NamespacePin.GetNamespace()}, nil
+	case *hubv1.PostRenderer_StrategicMergePatch:
+		return &StrategicMergePatchTransformer{Patches: kind.StrategicMergePatch.GetPatches()}, nil
+	case *hubv1.PostRenderer_JsonPatch:
+		return &JSONPatchTransformer{Patches: kind.JsonPatch.GetPatches()}, nil
+	case *hubv1.PostRenderer_Exec:
+		return &ExecTransformer{Command: kind.Exec.GetCommand(), Args: kind.Exec.GetArgs()}, nil
+	default:
+		return nil, errors.Errorf("unrecognized post-render transformer kind %T", kind)
+	}
+}
+
+// LabelAnnotationTransformer injects labels and annotations into every
+// resource.
+type LabelAnnotationTransformer struct {
+	Labels      map[string]string
+	Annotations map[string]string
+}
+
+func (t *LabelAnnotationTransformer) Name() string { return "labels-and-annotations" }
+
+func (t *LabelAnnotationTransformer) Transform(_ context.Context, resources kuberesource.UnstructuredResources) (kuberesource.UnstructuredResources, error) {
+	for _, resource := range resources {
+		if len(t.Labels) > 0 {
+			labels := resource.GetLabels()
+			if labels == nil {
+				labels = make(map[string]string)
+			}
+			for k, v := range t.Labels {
+				labels[k] = v
+			}
+			resource.SetLabels(labels)
+		}
+		if len(t.Annotations) > 0 {
+			annotations := resource.GetAnnotations()
+			if annotations == nil {
+				annotations = make(map[string]string)
+			}
+			for k, v := range t.Annotations {
+				annotations[k] = v
+			}
+			resource.SetAnnotations(annotations)
+		}
+	}
+	return resources, nil
+}
+
+// NamespaceTransformer pins every resource to a single namespace,
+// overriding whatever the chart rendered.
+type NamespaceTransformer struct {
+	Namespace string
+}
+
+func (t *NamespaceTransformer) Name() string { return "namespace-pin" }
+
+func (t *NamespaceTransformer) Transform(_ context.Context, resources kuberesource.UnstructuredResources) (kuberesource.UnstructuredResources, error) {
+	for _, resource := range resources {
+		resource.SetNamespace(t.Namespace)
+	}
+	return resources, nil
+}
+
+// ImageTagRewriteTransformer rewrites container images matching one of the
+// configured keys (e.g. "gcr.io/my-project/app") to its mapped
+// replacement, across every pod spec template it finds.
+type ImageTagRewriteTransformer struct {
+	// Rewrites maps a source image (optionally including a tag) to the
+	// image it should be replaced with.
+	Rewrites map[string]string
+}
+
+func (t *ImageTagRewriteTransformer) Name() string { return "image-tag-rewrite" }
+
+func (t *ImageTagRewriteTransformer) Transform(_ context.Context, resources kuberesource.UnstructuredResources) (kuberesource.UnstructuredResources, error) {
+	for _, resource := range resources {
+		containerPaths := [][]string{
+			{"spec", "template", "spec", "containers"},
+			{"spec", "template", "spec", "initContainers"},
+			{"spec", "containers"},
+			{"spec", "initContainers"},
+		}
+		for _, path := range containerPaths {
+			containers, found, err := unstructured.NestedSlice(resource.Object, path...)
+			if err != nil || !found {
+				continue
+			}
+			changed := false
+			for i, c := range containers {
+				container, ok := c.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				image, _, _ := unstructured.NestedString(container, "image")
+				if replacement, ok := t.Rewrites[image]; ok {
+					container["image"] = replacement
+					containers[i] = container
+					changed = true
+				}
+			}
+			if changed {
+				if err := unstructured.SetNestedSlice(resource.Object, containers, path...); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	return resources, nil
+}
+
+// ExecTransformer shells out to an external post-renderer binary, sending
+// the rendered YAML on stdin and reading the transformed YAML back from
+// stdout, the same contract Helm's --post-renderer flag uses. It's how
+// arbitrary tools (kustomize, ytt, opa) layer onto the pipeline without
+// this package knowing about them.
+type ExecTransformer struct {
+	Command string
+	Args    []string
+}
+
+func (t *ExecTransformer) Name() string { return t.Command }
+
+func (t *ExecTransformer) Transform(ctx context.Context, resources kuberesource.UnstructuredResources) (kuberesource.UnstructuredResources, error) {
+	rendered, err := resources.ToYaml()
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := exec.CommandContext(ctx, t.Command, t.Args...)
+	cmd.Stdin = bytes.NewReader([]byte(rendered))
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, errors.Wrapf(err, "post-renderer %v: %v", t.Command, stderr.String())
+	}
+
+	return kuberesource.UnstructuredResourcesFromYaml(stdout.String())
+}
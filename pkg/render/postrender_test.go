@@ -0,0 +1,109 @@
+package render
+
+import (
+	"context"
+	"testing"
+
+	"github.com/solo-io/go-utils/installutils/kuberesource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func deployment(name string, containers ...map[string]interface{}) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion("apps/v1")
+	u.SetKind("Deployment")
+	u.SetName(name)
+	if err := unstructured.SetNestedSlice(u.Object, toSlice(containers), "spec", "template", "spec", "containers"); err != nil {
+		panic(err)
+	}
+	return u
+}
+
+func toSlice(containers []map[string]interface{}) []interface{} {
+	out := make([]interface{}, len(containers))
+	for i, c := range containers {
+		out[i] = c
+	}
+	return out
+}
+
+func TestLabelAnnotationTransformerAddsLabelsAndAnnotations(t *testing.T) {
+	resources := kuberesource.UnstructuredResources{configMap("ns-a", "config")}
+
+	transformer := &LabelAnnotationTransformer{
+		Labels:      map[string]string{"team": "mesh"},
+		Annotations: map[string]string{"owner": "platform"},
+	}
+
+	got, err := transformer.Transform(context.Background(), resources)
+	if err != nil {
+		t.Fatalf("Transform() error = %v", err)
+	}
+	if got[0].GetLabels()["team"] != "mesh" {
+		t.Fatalf("Transform() labels = %v, want team=mesh", got[0].GetLabels())
+	}
+	if got[0].GetAnnotations()["owner"] != "platform" {
+		t.Fatalf("Transform() annotations = %v, want owner=platform", got[0].GetAnnotations())
+	}
+}
+
+func TestImageTagRewriteTransformerRewritesMatchingImages(t *testing.T) {
+	resources := kuberesource.UnstructuredResources{
+		deployment("app",
+			map[string]interface{}{"name": "app", "image": "gcr.io/proj/app:1.0"},
+			map[string]interface{}{"name": "sidecar", "image": "gcr.io/proj/sidecar:1.0"},
+		),
+	}
+
+	transformer := &ImageTagRewriteTransformer{
+		Rewrites: map[string]string{"gcr.io/proj/app:1.0": "gcr.io/proj/app:2.0"},
+	}
+
+	got, err := transformer.Transform(context.Background(), resources)
+	if err != nil {
+		t.Fatalf("Transform() error = %v", err)
+	}
+
+	containers, _, err := unstructured.NestedSlice(got[0].Object, "spec", "template", "spec", "containers")
+	if err != nil {
+		t.Fatalf("NestedSlice() error = %v", err)
+	}
+
+	app := containers[0].(map[string]interface{})
+	if app["image"] != "gcr.io/proj/app:2.0" {
+		t.Fatalf("Transform() app image = %v, want the rewritten tag", app["image"])
+	}
+	sidecar := containers[1].(map[string]interface{})
+	if sidecar["image"] != "gcr.io/proj/sidecar:1.0" {
+		t.Fatalf("Transform() sidecar image = %v, want it left untouched", sidecar["image"])
+	}
+}
+
+func TestNamespaceTransformerPinsNamespace(t *testing.T) {
+	resources := kuberesource.UnstructuredResources{configMap("ns-a", "config")}
+
+	transformer := &NamespaceTransformer{Namespace: "ns-b"}
+	got, err := transformer.Transform(context.Background(), resources)
+	if err != nil {
+		t.Fatalf("Transform() error = %v", err)
+	}
+	if got[0].GetNamespace() != "ns-b" {
+		t.Fatalf("Transform() namespace = %v, want ns-b", got[0].GetNamespace())
+	}
+}
+
+func TestExecTransformerRunsCommandAndParsesOutput(t *testing.T) {
+	resources := kuberesource.UnstructuredResources{configMap("ns-a", "config")}
+
+	transformer := &ExecTransformer{
+		Command: "cat",
+	}
+
+	got, err := transformer.Transform(context.Background(), resources)
+	if err != nil {
+		t.Fatalf("Transform() error = %v", err)
+	}
+	if len(got) != 1 || got[0].GetName() != "config" {
+		t.Fatalf("Transform() = %v, want the piped-through resource unchanged", got)
+	}
+}
@@ -5,40 +5,33 @@ import (
 
 	"github.com/solo-io/go-utils/installutils/kuberesource"
 	v1 "github.com/solo-io/service-mesh-hub/api/v1"
+	"github.com/solo-io/service-mesh-hub/pkg/render/validation"
 )
 
 //go:generate mockgen -source=./renderer.go -package mocks -destination=./mocks/mock_render.go ManifestRenderer
 
+// ManifestRenderer is the original, single-method entry point for computing
+// install manifests.
+//
+// Deprecated: construct a Template or Install action instead; they expose the
+// same pipeline as composable stages with dry-run, kube-version, atomic, and
+// timeout options.
 type ManifestRenderer interface {
 	// Given the spec and values inputs, generate a set of kube resources that represent the exact install manifest.
 	ComputeResourcesForApplication(ctx context.Context, inputs ValuesInputs, spec *v1.VersionedApplicationSpec) (kuberesource.UnstructuredResources, error)
 }
 
 type manifestRenderer struct {
+	template *Template
 }
 
-func NewManifestRenderer() ManifestRenderer {
-	return &manifestRenderer{}
+// Deprecated: use NewTemplate or NewInstall.
+func NewManifestRenderer(validate validation.ValidateResourceDependencies) ManifestRenderer {
+	template := NewTemplate()
+	template.Validate = validate
+	return &manifestRenderer{template: template}
 }
 
 func (m *manifestRenderer) ComputeResourcesForApplication(ctx context.Context, inputs ValuesInputs, spec *v1.VersionedApplicationSpec) (kuberesource.UnstructuredResources, error) {
-	if err := ValidateInputs(inputs, *spec); err != nil {
-		return nil, err
-	}
-
-	inputs, err := ExecInputValuesTemplates(inputs)
-	if err != nil {
-		return nil, FailedRenderValueTemplatesError(err)
-	}
-
-	manifests, err := GetManifestsFromApplicationSpec(ctx, inputs, spec)
-	if err != nil {
-		return nil, err
-	}
-
-	rawResources, err := ApplyLayers(ctx, inputs, manifests)
-	if err != nil {
-		return nil, err
-	}
-	return FilterByLabel(ctx, spec, rawResources), nil
+	return m.template.Run(ctx, inputs, spec)
 }
@@ -0,0 +1,72 @@
+package render
+
+import (
+	"context"
+	"regexp"
+
+	errors "github.com/rotisserie/eris"
+)
+
+// SecretResolver resolves a vals-style secret reference URI (vault://,
+// awssm://, ref+file://, ...) into its plaintext value.
+type SecretResolver interface {
+	// Scheme is the URI scheme this resolver handles, e.g. "vault" or
+	// "ref+file".
+	Scheme() string
+	Resolve(ctx context.Context, uri string) (string, error)
+}
+
+var secretResolvers = map[string]SecretResolver{}
+
+// RegisterSecretResolver makes a SecretResolver available to
+// resolveSecretRefs, keyed by its Scheme.
+func RegisterSecretResolver(resolver SecretResolver) {
+	secretResolvers[resolver.Scheme()] = resolver
+}
+
+var secretRefPattern = regexp.MustCompile(`^([a-zA-Z0-9+]+)://`)
+
+// resolveSecretRefs walks a values map and replaces any leaf string that
+// looks like a registered secret reference (scheme://...) with the value
+// the matching SecretResolver returns for it. Values with an unrecognized
+// scheme, or that aren't references at all, are left untouched.
+func resolveSecretRefs(ctx context.Context, values map[string]interface{}) (map[string]interface{}, error) {
+	resolved := make(map[string]interface{}, len(values))
+	for key, value := range values {
+		v, err := resolveSecretRefValue(ctx, value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "resolving secret reference for %v", key)
+		}
+		resolved[key] = v
+	}
+	return resolved, nil
+}
+
+func resolveSecretRefValue(ctx context.Context, value interface{}) (interface{}, error) {
+	switch typed := value.(type) {
+	case string:
+		match := secretRefPattern.FindStringSubmatch(typed)
+		if match == nil {
+			return typed, nil
+		}
+		resolver, ok := secretResolvers[match[1]]
+		if !ok {
+			return typed, nil
+		}
+		return resolver.Resolve(ctx, typed)
+	case map[string]interface{}:
+		return resolveSecretRefs(ctx, typed)
+	case []interface{}:
+		resolved := make([]interface{}, len(typed))
+		for i, element := range typed {
+			v, err := resolveSecretRefValue(ctx, element)
+			if err != nil {
+				return nil, err
+			}
+			resolved[i] = v
+		}
+		return resolved, nil
+	default:
+		return value, nil
+	}
+}
@@ -0,0 +1,83 @@
+package render
+
+import (
+	"context"
+	"testing"
+)
+
+type fakeSecretResolver struct {
+	scheme string
+}
+
+func (f *fakeSecretResolver) Scheme() string { return f.scheme }
+
+func (f *fakeSecretResolver) Resolve(_ context.Context, uri string) (string, error) {
+	return "resolved:" + uri, nil
+}
+
+func TestResolveSecretRefsResolvesTopLevelString(t *testing.T) {
+	RegisterSecretResolver(&fakeSecretResolver{scheme: "vault"})
+
+	values := map[string]interface{}{
+		"password": "vault://secret/db#password",
+	}
+
+	resolved, err := resolveSecretRefs(context.Background(), values)
+	if err != nil {
+		t.Fatalf("resolveSecretRefs() error = %v", err)
+	}
+	if resolved["password"] != "resolved:vault://secret/db#password" {
+		t.Fatalf("resolveSecretRefs() = %v, want the top-level ref resolved", resolved)
+	}
+}
+
+func TestResolveSecretRefsResolvesRefsNestedInLists(t *testing.T) {
+	RegisterSecretResolver(&fakeSecretResolver{scheme: "vault"})
+
+	values := map[string]interface{}{
+		"env": []interface{}{
+			map[string]interface{}{
+				"name":  "DB_PASSWORD",
+				"value": "vault://secret/db#password",
+			},
+			map[string]interface{}{
+				"name":  "DB_HOST",
+				"value": "localhost",
+			},
+		},
+	}
+
+	resolved, err := resolveSecretRefs(context.Background(), values)
+	if err != nil {
+		t.Fatalf("resolveSecretRefs() error = %v", err)
+	}
+
+	env, ok := resolved["env"].([]interface{})
+	if !ok || len(env) != 2 {
+		t.Fatalf("resolveSecretRefs() env = %v, want a 2-element list", resolved["env"])
+	}
+
+	entry, ok := env[0].(map[string]interface{})
+	if !ok || entry["value"] != "resolved:vault://secret/db#password" {
+		t.Fatalf("resolveSecretRefs() env[0] = %v, want the nested ref resolved", env[0])
+	}
+
+	unchanged, ok := env[1].(map[string]interface{})
+	if !ok || unchanged["value"] != "localhost" {
+		t.Fatalf("resolveSecretRefs() env[1] = %v, want the non-ref value left untouched", env[1])
+	}
+}
+
+func TestResolveSecretRefsLeavesUnrecognizedSchemeUntouched(t *testing.T) {
+	values := map[string]interface{}{
+		"token": "unregistered-scheme://foo",
+	}
+
+	resolved, err := resolveSecretRefs(context.Background(), values)
+	if err != nil {
+		t.Fatalf("resolveSecretRefs() error = %v", err)
+	}
+	if resolved["token"] != "unregistered-scheme://foo" {
+		t.Fatalf("resolveSecretRefs() = %v, want an unrecognized scheme left untouched", resolved)
+	}
+}
@@ -0,0 +1,17 @@
+package validation
+
+import (
+	hubv1 "github.com/solo-io/service-mesh-hub/api/v1"
+)
+
+// ValidateResourceDependencies checks that the resource dependencies declared
+// by a selected layer option (required meshes, CRDs, etc.) are actually
+// satisfied in the target cluster before manifests are rendered from it.
+type ValidateResourceDependencies func(deps []*hubv1.ResourceDependency) error
+
+// NoopValidateResources performs no validation. It is the default used by
+// callers, such as tests and CLI template/lint runs, that have no live
+// cluster to check resource dependencies against.
+func NoopValidateResources(_ []*hubv1.ResourceDependency) error {
+	return nil
+}
@@ -0,0 +1,273 @@
+package render
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+
+	sprig "github.com/Masterminds/sprig/v3"
+	errors "github.com/rotisserie/eris"
+	"github.com/solo-io/go-utils/installutils"
+	"github.com/solo-io/go-utils/installutils/helmchart"
+	hubv1 "github.com/solo-io/service-mesh-hub/api/v1"
+)
+
+var (
+	FailedToParseValueTemplateError = func(name string, err error) error {
+		return errors.Wrapf(err, "error parsing value template %v", name)
+	}
+
+	FailedToExecuteValueTemplateError = func(name string, err error) error {
+		return errors.Wrapf(err, "error executing value template %v", name)
+	}
+
+	FailedToFetchInstallationFilesError = func(err error) error {
+		return errors.Wrapf(err, "error fetching installation archive files for value templates")
+	}
+)
+
+// maxValueTemplatePasses bounds how many times ExecInputValuesTemplates
+// re-renders Params so that a param's value may reference another param
+// through .Values; most specs converge in a single pass.
+const maxValueTemplatePasses = 3
+
+// ReleaseContext is the .Release data made available to value templates.
+type ReleaseContext struct {
+	Name      string
+	Namespace string
+	IsUpgrade bool
+}
+
+// MeshContext is the .Mesh data made available to value templates.
+type MeshContext struct {
+	Name              string
+	Namespace         string
+	Type              string
+	DiscoveredVersion string
+}
+
+// Files is the .Files data made available to value templates, letting a
+// spec author reference a sibling file from the spec's installation archive
+// by path. It's populated by filesFromInstallationSpec, not a proto field.
+type Files map[string]string
+
+// Get returns the named file's contents, or the empty string if it isn't
+// present.
+func (f Files) Get(name string) string {
+	return f[name]
+}
+
+// TemplateContext is the data available to SpecDefinedValues,
+// UserDefinedValues, and Params templates: .Release, .Mesh, .Values (the
+// values resolved so far), and .Files.
+type TemplateContext struct {
+	Release ReleaseContext
+	Mesh    MeshContext
+	Values  map[string]interface{}
+	Files   Files
+}
+
+// ExecInputValuesTemplates renders the template actions (text delimited by
+// "{{" and "}}") in SpecDefinedValues, UserDefinedValues, and Params, using
+// Sprig's function set and the shared TemplateContext. It re-renders Params
+// up to maxValueTemplatePasses times so that a param referencing another
+// param (through .Values) resolves once its dependency has.
+func ExecInputValuesTemplates(ctx context.Context, inputs ValuesInputs, spec *hubv1.VersionedApplicationSpec) (ValuesInputs, error) {
+	base := template.New("values").Funcs(sprig.TxtFuncMap())
+	base, err := base.Parse(templateDefinitions(spec))
+	if err != nil {
+		return ValuesInputs{}, FailedToParseValueTemplateError("definitions", err)
+	}
+
+	files, err := filesFromInstallationSpec(ctx, spec)
+	if err != nil {
+		return ValuesInputs{}, err
+	}
+
+	for pass := 0; pass < maxValueTemplatePasses; pass++ {
+		before := renderedInputsFingerprint(inputs)
+
+		inputs, err = renderValuesOnce(ctx, base, inputs, spec, files)
+		if err != nil {
+			return ValuesInputs{}, err
+		}
+
+		if renderedInputsFingerprint(inputs) == before {
+			break
+		}
+	}
+
+	return inputs, nil
+}
+
+// renderValuesOnce runs SpecDefinedValues, UserDefinedValues, and each
+// Params entry through one pass of the template engine.
+func renderValuesOnce(ctx context.Context, base *template.Template, inputs ValuesInputs, spec *hubv1.VersionedApplicationSpec, files Files) (ValuesInputs, error) {
+	data := newTemplateContext(ctx, inputs, spec, files)
+
+	specValues, err := renderValueTemplate(base, "specValues", inputs.SpecDefinedValues, data)
+	if err != nil {
+		return ValuesInputs{}, err
+	}
+	inputs.SpecDefinedValues = specValues
+
+	userValues, err := renderValueTemplate(base, "userValues", inputs.UserDefinedValues, data)
+	if err != nil {
+		return ValuesInputs{}, err
+	}
+	inputs.UserDefinedValues = userValues
+
+	for paramName, paramValue := range inputs.Params {
+		rendered, err := renderValueTemplate(base, paramName, paramValue, data)
+		if err != nil {
+			return ValuesInputs{}, err
+		}
+		inputs.Params[paramName] = rendered
+	}
+
+	return inputs, nil
+}
+
+// renderValueTemplate parses text against a clone of base (so named
+// {{ define }} blocks declared on the spec stay in scope) and executes it
+// with data, returning an error instead of panicking the way template.Must
+// would. Parse errors name the template and line but not the column;
+// execution errors give both line and column.
+func renderValueTemplate(base *template.Template, name, text string, data TemplateContext) (string, error) {
+	tpl, err := base.Clone()
+	if err != nil {
+		return "", FailedToParseValueTemplateError(name, err)
+	}
+	tpl, err = tpl.New(name).Parse(text)
+	if err != nil {
+		return "", FailedToParseValueTemplateError(name, err)
+	}
+
+	buf := new(bytes.Buffer)
+	if err := tpl.Execute(buf, data); err != nil {
+		return "", FailedToExecuteValueTemplateError(name, err)
+	}
+	return buf.String(), nil
+}
+
+// templateDefinitions renders the named partials a VersionedApplicationSpec
+// declares into a block of {{ define }} statements so they're in scope for
+// every value template parsed against base.
+func templateDefinitions(spec *hubv1.VersionedApplicationSpec) string {
+	var sb strings.Builder
+	for name, body := range spec.GetTemplateDefinitions() {
+		sb.WriteString(fmt.Sprintf("{{ define %q }}%s{{ end }}\n", name, body))
+	}
+	return sb.String()
+}
+
+// newTemplateContext assembles the .Release/.Mesh/.Values/.Files data a
+// value template can reference. .Values is a best-effort coalesce of what's
+// been resolved so far; it is not the final, layered values document
+// ComputeValueOverrides produces, since templating runs ahead of that.
+func newTemplateContext(ctx context.Context, inputs ValuesInputs, spec *hubv1.VersionedApplicationSpec, files Files) TemplateContext {
+	return TemplateContext{
+		Release: ReleaseContext{
+			Name:      inputs.Name,
+			Namespace: inputs.InstallNamespace,
+			IsUpgrade: inputs.IsUpgrade,
+		},
+		Mesh: MeshContext{
+			Name:              inputs.MeshRef.Name,
+			Namespace:         inputs.MeshRef.Namespace,
+			Type:              inputs.MeshType,
+			DiscoveredVersion: inputs.MeshDiscoveredVersion,
+		},
+		Values: currentValuesSnapshot(ctx, inputs),
+		Files:  files,
+	}
+}
+
+// filesFromInstallationSpec fetches the sibling files of the spec's
+// installation archive once, ahead of the multi-pass template loop, so
+// .Files in a value template reflects what's actually in the chart/manifest
+// archive rather than a static, hand-maintained proto field. Installation
+// kinds that don't resolve to a fetchable archive at this point in the
+// pipeline (plugin specs, OCI charts, multi-step installs) have no files
+// available to value templates and resolve to an empty Files.
+func filesFromInstallationSpec(ctx context.Context, spec *hubv1.VersionedApplicationSpec) (Files, error) {
+	switch installationSpec := spec.GetInstallationSpec().(type) {
+	case *hubv1.VersionedApplicationSpec_HelmArchive:
+		return filesFromTgz(installationSpec.HelmArchive.GetUri())
+	case *hubv1.VersionedApplicationSpec_ManifestsArchive:
+		return filesFromTgz(installationSpec.ManifestsArchive.GetUri())
+	case *hubv1.VersionedApplicationSpec_GithubChart:
+		ref := helmchart.GithubChartRef{
+			Owner:          installationSpec.GithubChart.GetOrg(),
+			Repo:           installationSpec.GithubChart.GetRepo(),
+			Ref:            installationSpec.GithubChart.GetRef(),
+			ChartDirectory: installationSpec.GithubChart.GetDirectory(),
+		}
+		files, err := helmchart.GetFilesFromGithub(ctx, ref)
+		if err != nil {
+			return nil, FailedToFetchInstallationFilesError(err)
+		}
+		return Files(files), nil
+	default:
+		return Files{}, nil
+	}
+}
+
+// filesFromTgz downloads and extracts uri, returning its contents keyed by
+// path relative to the archive root.
+func filesFromTgz(uri string) (Files, error) {
+	if uri == "" {
+		return Files{}, nil
+	}
+	files, err := installutils.GetFilesFromRemoteTar(uri)
+	if err != nil {
+		return nil, FailedToFetchInstallationFilesError(err)
+	}
+	return Files(files), nil
+}
+
+// currentValuesSnapshot coalesces the spec, param, and user values known so
+// far, skipping any that don't parse yet (they may still contain
+// unresolved template actions). It exists only to populate .Values for
+// templating, not as a replacement for ComputeValueOverrides.
+func currentValuesSnapshot(ctx context.Context, inputs ValuesInputs) map[string]interface{} {
+	valuesMap := make(map[string]interface{})
+
+	if specValues, err := ConvertYamlStringToNestedMap(inputs.SpecDefinedValues); err == nil {
+		valuesMap = CoalesceValuesMap(ctx, valuesMap, specValues)
+	}
+	if paramValues, err := ConvertParamsToNestedMap(inputs.Params); err == nil {
+		valuesMap = CoalesceValuesMap(ctx, valuesMap, paramValues)
+	}
+	if userValues, err := ConvertYamlStringToNestedMap(inputs.UserDefinedValues); err == nil {
+		valuesMap = CoalesceValuesMap(ctx, valuesMap, userValues)
+	}
+
+	return valuesMap
+}
+
+// renderedInputsFingerprint is a cheap equality check used to detect that a
+// render pass changed nothing, so ExecInputValuesTemplates can stop early
+// instead of always running maxValueTemplatePasses times.
+func renderedInputsFingerprint(inputs ValuesInputs) string {
+	paramNames := make([]string, 0, len(inputs.Params))
+	for name := range inputs.Params {
+		paramNames = append(paramNames, name)
+	}
+	sort.Strings(paramNames)
+
+	var sb strings.Builder
+	sb.WriteString(inputs.SpecDefinedValues)
+	sb.WriteString("\x00")
+	sb.WriteString(inputs.UserDefinedValues)
+	for _, name := range paramNames {
+		sb.WriteString("\x00")
+		sb.WriteString(name)
+		sb.WriteString("=")
+		sb.WriteString(inputs.Params[name])
+	}
+	return sb.String()
+}
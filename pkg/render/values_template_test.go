@@ -0,0 +1,40 @@
+package render
+
+import (
+	"context"
+	"testing"
+
+	hubv1 "github.com/solo-io/service-mesh-hub/api/v1"
+)
+
+func TestFilesFromInstallationSpecUnfetchableKindsAreEmpty(t *testing.T) {
+	spec := &hubv1.VersionedApplicationSpec{
+		InstallationSpec: &hubv1.VersionedApplicationSpec_PluginSpec{
+			PluginSpec: &hubv1.PluginInstallationSpec{Kind: "kustomize"},
+		},
+	}
+
+	files, err := filesFromInstallationSpec(context.Background(), spec)
+	if err != nil {
+		t.Fatalf("filesFromInstallationSpec() error = %v", err)
+	}
+	if len(files) != 0 {
+		t.Fatalf("filesFromInstallationSpec() = %v, want empty Files for a plugin spec", files)
+	}
+}
+
+func TestFilesFromInstallationSpecEmptyTgzUriIsEmpty(t *testing.T) {
+	spec := &hubv1.VersionedApplicationSpec{
+		InstallationSpec: &hubv1.VersionedApplicationSpec_HelmArchive{
+			HelmArchive: &hubv1.TgzLocation{},
+		},
+	}
+
+	files, err := filesFromInstallationSpec(context.Background(), spec)
+	if err != nil {
+		t.Fatalf("filesFromInstallationSpec() error = %v", err)
+	}
+	if len(files) != 0 {
+		t.Fatalf("filesFromInstallationSpec() = %v, want empty Files for an empty archive URI", files)
+	}
+}